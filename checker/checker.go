@@ -0,0 +1,518 @@
+// Package checker measures page-load metrics for a set of sites with
+// chromedp and ranks them against each other.
+package checker
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chromedp/cdproto/network"
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/cdproto/runtime"
+	"github.com/chromedp/chromedp"
+)
+
+// SiteMetrics is a single measurement of a site taken during one run.
+type SiteMetrics struct {
+	URL string
+
+	// Latency and TTR are kept for backwards compatibility with existing
+	// rankings and the samples table: Latency is the time to the first
+	// network response (equivalent to TTFB) and TTR is the time to the
+	// firstMeaningfulPaint lifecycle event.
+	Latency time.Duration
+	TTR     time.Duration
+
+	TTFB        time.Duration
+	FCP         time.Duration
+	LCP         time.Duration
+	CLS         float64
+	NetworkIdle time.Duration
+
+	Error error
+}
+
+// SiteStats aggregates the SiteMetrics samples collected for a URL over
+// however many runs went into computing it.
+type SiteStats struct {
+	URL        string
+	AvgLatency time.Duration
+	MinLatency time.Duration
+	MaxLatency time.Duration
+	AvgTTR     time.Duration
+	MinTTR     time.Duration
+	MaxTTR     time.Duration
+
+	AvgFCP time.Duration
+	MinFCP time.Duration
+	MaxFCP time.Duration
+
+	AvgLCP time.Duration
+	MinLCP time.Duration
+	MaxLCP time.Duration
+
+	AvgCLS float64
+	MinCLS float64
+	MaxCLS float64
+
+	AvgNetworkIdle time.Duration
+	MinNetworkIdle time.Duration
+	MaxNetworkIdle time.Duration
+
+	FailureCount int
+	SuccessCount int
+}
+
+// Metric identifies one of the measurements tracked in SiteStats that
+// RankSites can rank sites by.
+type Metric string
+
+// The metrics RankSites knows how to rank by.
+const (
+	MetricLatency     Metric = "latency"
+	MetricTTR         Metric = "ttr"
+	MetricFCP         Metric = "fcp"
+	MetricLCP         Metric = "lcp"
+	MetricCLS         Metric = "cls"
+	MetricNetworkIdle Metric = "network_idle"
+)
+
+// MetricWeight pairs a Metric with how heavily it counts toward
+// RankedSite.CombinedRank.
+type MetricWeight struct {
+	Metric Metric  `json:"metric"`
+	Weight float64 `json:"weight"`
+}
+
+// DefaultMetricWeights reproduces the checker's original behaviour: an
+// even split between Latency and TTR.
+var DefaultMetricWeights = []MetricWeight{
+	{MetricLatency, 1},
+	{MetricTTR, 1},
+}
+
+// validMetrics are the Metric values metricValue knows how to evaluate.
+// ParseMetricWeights rejects anything else rather than let an unrecognized
+// metric silently tie every site at 0 in metricValue's default case.
+var validMetrics = map[Metric]bool{
+	MetricLatency:     true,
+	MetricTTR:         true,
+	MetricFCP:         true,
+	MetricLCP:         true,
+	MetricCLS:         true,
+	MetricNetworkIdle: true,
+}
+
+// ParseMetricWeights parses a "metric:weight,metric:weight,..." spec, e.g.
+// "latency:1,ttr:1,lcp:2". An empty spec returns nil, which RankSites
+// treats as DefaultMetricWeights.
+func ParseMetricWeights(spec string) ([]MetricWeight, error) {
+	if spec == "" {
+		return nil, nil
+	}
+
+	var weights []MetricWeight
+	for _, pair := range strings.Split(spec, ",") {
+		metric, weightStr, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("expected metric:weight, got %q", pair)
+		}
+		if !validMetrics[Metric(metric)] {
+			return nil, fmt.Errorf("unknown metric %q", metric)
+		}
+		weight, err := strconv.ParseFloat(weightStr, 64)
+		if err != nil {
+			return nil, fmt.Errorf("weight for %q: %w", metric, err)
+		}
+		weights = append(weights, MetricWeight{Metric: Metric(metric), Weight: weight})
+	}
+	return weights, nil
+}
+
+// FormatMetricWeights renders weights back into the "metric:weight,..."
+// form ParseMetricWeights accepts, for round-tripping through the
+// dashboard's settings form.
+func FormatMetricWeights(weights []MetricWeight) string {
+	parts := make([]string, len(weights))
+	for i, mw := range weights {
+		parts[i] = fmt.Sprintf("%s:%g", mw.Metric, mw.Weight)
+	}
+	return strings.Join(parts, ",")
+}
+
+func metricValue(s *SiteStats, m Metric) float64 {
+	switch m {
+	case MetricLatency:
+		return float64(s.AvgLatency)
+	case MetricTTR:
+		return float64(s.AvgTTR)
+	case MetricFCP:
+		return float64(s.AvgFCP)
+	case MetricLCP:
+		return float64(s.AvgLCP)
+	case MetricCLS:
+		return s.AvgCLS
+	case MetricNetworkIdle:
+		return float64(s.AvgNetworkIdle)
+	default:
+		return 0
+	}
+}
+
+// RankedSite attaches a per-metric rank and a combined rank to a SiteStats.
+type RankedSite struct {
+	*SiteStats
+	MetricRanks  map[Metric]int
+	CombinedRank float64
+}
+
+// Rank returns the site's rank for m, or 0 if m wasn't part of the
+// MetricWeight set RankSites was called with.
+func (r RankedSite) Rank(m Metric) int {
+	return r.MetricRanks[m]
+}
+
+// vitalsGracePeriod is how long MeasureMetrics waits after the page
+// reports networkAlmostIdle for trailing LCP/CLS observer callbacks to
+// reach the sendVital binding before it finalizes the sample.
+const vitalsGracePeriod = 250 * time.Millisecond
+
+// MeasureMetrics loads url in a headless browser and collects Core Web
+// Vitals-grade timings: TTFB (first network response), FCP and LCP (from
+// PerformanceObserver entries relayed through the sendVital binding), CLS
+// (cumulative layout shift score, also via sendVital), TTR (the
+// firstMeaningfulPaint lifecycle event) and NetworkIdle (the
+// networkAlmostIdle lifecycle event, which is what MeasureMetrics actually
+// waits on before returning).
+func MeasureMetrics(url string) (SiteMetrics, error) {
+	ctx, cancel := chromedp.NewContext(context.Background())
+	defer cancel()
+
+	ctx, cancel = context.WithTimeout(ctx, 45*time.Second)
+	defer cancel()
+
+	var ttfb, ttr, fcp, lcp, networkIdle time.Duration
+	var cls float64
+	start := time.Now()
+
+	// Signalled once the page reaches networkAlmostIdle; that's the point
+	// we consider the page done enough to report a sample for.
+	idle := make(chan bool, 1)
+
+	chromedp.ListenTarget(ctx, func(ev interface{}) {
+		switch ev := ev.(type) {
+		case *network.EventResponseReceived:
+			if ttfb == 0 {
+				ttfb = time.Since(start)
+			}
+		case *page.EventLifecycleEvent:
+			switch ev.Name {
+			case "firstMeaningfulPaint":
+				if ttr == 0 {
+					ttr = time.Since(start)
+				}
+			case "networkAlmostIdle":
+				if networkIdle == 0 {
+					networkIdle = time.Since(start)
+				}
+				select {
+				case idle <- true:
+				default:
+				}
+			}
+		case *runtime.EventBindingCalled:
+			if ev.Name != "sendVital" {
+				return
+			}
+			payload, err := parseVitalPayload(ev.Payload)
+			if err != nil {
+				return
+			}
+			value := time.Duration(payload.Value * float64(time.Millisecond))
+			switch payload.Name {
+			case "fcp":
+				if fcp == 0 {
+					fcp = value
+				}
+			case "lcp":
+				lcp = value
+			case "cls":
+				cls = payload.Value
+			}
+		}
+	})
+
+	err := chromedp.Run(ctx,
+		runtime.AddBinding("sendVital"),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			_, err := page.AddScriptToEvaluateOnNewDocument(vitalsShim).Do(ctx)
+			return err
+		}),
+		page.SetLifecycleEventsEnabled(true),
+		network.Enable(),
+		chromedp.Navigate(url),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			select {
+			case <-idle:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			select {
+			case <-time.After(vitalsGracePeriod):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+			return nil
+		}),
+	)
+
+	if err != nil {
+		return SiteMetrics{URL: url, Error: err}, err
+	}
+
+	return SiteMetrics{
+		URL:         url,
+		Latency:     ttfb,
+		TTR:         ttr,
+		TTFB:        ttfb,
+		FCP:         fcp,
+		LCP:         lcp,
+		CLS:         cls,
+		NetworkIdle: networkIdle,
+	}, nil
+}
+
+// TestMetrics measures every site in sites, running up to concurrencyLimit
+// measurements in parallel.
+func TestMetrics(sites []string, concurrencyLimit int) []SiteMetrics {
+	results := make([]SiteMetrics, 0, len(sites))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	semaphore := make(chan struct{}, concurrencyLimit)
+
+	for _, site := range sites {
+		wg.Add(1)
+		go func(url string) {
+			defer wg.Done()
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			metrics, _ := MeasureMetrics(url)
+			mu.Lock()
+			results = append(results, metrics)
+			mu.Unlock()
+		}(site)
+	}
+
+	wg.Wait()
+	return results
+}
+
+// RunMetricsTests runs TestMetrics runs times, sleeping briefly between
+// runs, and returns every run's results.
+func RunMetricsTests(sites []string, runs, concurrencyLimit int) [][]SiteMetrics {
+	allResults := make([][]SiteMetrics, runs)
+
+	for i := 0; i < runs; i++ {
+		log.Printf("Starting run %d of %d", i+1, runs)
+		results := TestMetrics(sites, concurrencyLimit)
+		allResults[i] = results
+
+		log.Printf("Results for run %d:", i+1)
+		for _, result := range results {
+			if result.Error != nil {
+				log.Printf("%s: Error: %v", result.URL, result.Error)
+			} else {
+				log.Printf("%s: Latency: %v, TTR: %v", result.URL, result.Latency, result.TTR)
+			}
+		}
+		log.Println()
+
+		if i < runs-1 {
+			time.Sleep(3 * time.Second)
+		}
+	}
+
+	return allResults
+}
+
+// CalculateStats folds a batch of runs (as produced by RunMetricsTests)
+// into per-URL SiteStats. It only ever sees the single batch passed in;
+// callers that want stats over a longer rolling window should source
+// them from store.Store.StatsWindow instead.
+func CalculateStats(allResults [][]SiteMetrics) map[string]*SiteStats {
+	stats := make(map[string]*SiteStats)
+
+	for _, run := range allResults {
+		for _, result := range run {
+			if _, exists := stats[result.URL]; !exists {
+				stats[result.URL] = &SiteStats{
+					URL:            result.URL,
+					MinLatency:     result.Latency,
+					MaxLatency:     result.Latency,
+					MinTTR:         result.TTR,
+					MaxTTR:         result.TTR,
+					MinFCP:         result.FCP,
+					MaxFCP:         result.FCP,
+					MinLCP:         result.LCP,
+					MaxLCP:         result.LCP,
+					MinCLS:         result.CLS,
+					MaxCLS:         result.CLS,
+					MinNetworkIdle: result.NetworkIdle,
+					MaxNetworkIdle: result.NetworkIdle,
+				}
+			}
+
+			s := stats[result.URL]
+			if result.Error == nil {
+				s.AvgLatency += result.Latency
+				s.AvgTTR += result.TTR
+				s.AvgFCP += result.FCP
+				s.AvgLCP += result.LCP
+				s.AvgCLS += result.CLS
+				s.AvgNetworkIdle += result.NetworkIdle
+				s.SuccessCount++
+
+				if result.Latency < s.MinLatency {
+					s.MinLatency = result.Latency
+				}
+				if result.Latency > s.MaxLatency {
+					s.MaxLatency = result.Latency
+				}
+				if result.TTR < s.MinTTR {
+					s.MinTTR = result.TTR
+				}
+				if result.TTR > s.MaxTTR {
+					s.MaxTTR = result.TTR
+				}
+				if result.FCP < s.MinFCP {
+					s.MinFCP = result.FCP
+				}
+				if result.FCP > s.MaxFCP {
+					s.MaxFCP = result.FCP
+				}
+				if result.LCP < s.MinLCP {
+					s.MinLCP = result.LCP
+				}
+				if result.LCP > s.MaxLCP {
+					s.MaxLCP = result.LCP
+				}
+				if result.CLS < s.MinCLS {
+					s.MinCLS = result.CLS
+				}
+				if result.CLS > s.MaxCLS {
+					s.MaxCLS = result.CLS
+				}
+				if result.NetworkIdle < s.MinNetworkIdle {
+					s.MinNetworkIdle = result.NetworkIdle
+				}
+				if result.NetworkIdle > s.MaxNetworkIdle {
+					s.MaxNetworkIdle = result.NetworkIdle
+				}
+			} else {
+				s.FailureCount++
+			}
+		}
+	}
+
+	for _, s := range stats {
+		if s.SuccessCount > 0 {
+			n := time.Duration(s.SuccessCount)
+			s.AvgLatency /= n
+			s.AvgTTR /= n
+			s.AvgFCP /= n
+			s.AvgLCP /= n
+			s.AvgNetworkIdle /= n
+			s.AvgCLS /= float64(s.SuccessCount)
+		}
+	}
+
+	return stats
+}
+
+// RankSites ranks the given stats independently by each metric in weights
+// and combines the per-metric ranks into a weighted-average CombinedRank.
+// A nil or empty weights ranks by DefaultMetricWeights, reproducing the
+// checker's original latency+TTR behaviour. stats may come from a single
+// batch (CalculateStats) or from a rolling N-day window
+// (store.Store.StatsWindow) - RankSites doesn't care which, since both
+// produce the same map[string]*SiteStats shape.
+func RankSites(stats map[string]*SiteStats, weights []MetricWeight) []RankedSite {
+	if len(weights) == 0 {
+		weights = DefaultMetricWeights
+	}
+
+	sites := make([]RankedSite, 0, len(stats))
+	for _, s := range stats {
+		if s.SuccessCount > 0 {
+			sites = append(sites, RankedSite{SiteStats: s, MetricRanks: make(map[Metric]int, len(weights))})
+		}
+	}
+
+	for _, mw := range weights {
+		sort.Slice(sites, func(i, j int) bool {
+			return metricValue(sites[i].SiteStats, mw.Metric) < metricValue(sites[j].SiteStats, mw.Metric)
+		})
+		rank := 1
+		for i := range sites {
+			if i > 0 && metricValue(sites[i].SiteStats, mw.Metric) != metricValue(sites[i-1].SiteStats, mw.Metric) {
+				rank = i + 1
+			}
+			sites[i].MetricRanks[mw.Metric] = rank
+		}
+	}
+
+	var totalWeight float64
+	for _, mw := range weights {
+		totalWeight += mw.Weight
+	}
+
+	for i := range sites {
+		var weightedSum float64
+		for _, mw := range weights {
+			weightedSum += mw.Weight * float64(sites[i].MetricRanks[mw.Metric])
+		}
+		if totalWeight > 0 {
+			sites[i].CombinedRank = weightedSum / totalWeight
+		}
+	}
+
+	// Sort by Combined Rank
+	sort.Slice(sites, func(i, j int) bool {
+		return sites[i].CombinedRank < sites[j].CombinedRank
+	})
+
+	return sites
+}
+
+// SelectFlashscoreURL picks the fastest site, breaking near-ties (within
+// thresholdPercent of each other) in favour of the top site 90% of the time
+// so traffic isn't pinned to a single host indefinitely.
+func SelectFlashscoreURL(rankedSites []RankedSite, thresholdPercent float64) string {
+	if len(rankedSites) < 2 {
+		return rankedSites[0].URL
+	}
+
+	first := rankedSites[0]
+	second := rankedSites[1]
+	gap := second.CombinedRank - first.CombinedRank
+	percentageDiff := (gap / first.CombinedRank) * 100
+
+	if percentageDiff <= thresholdPercent {
+		if rand.Float64() < 0.9 {
+			return first.URL
+		} else {
+			return second.URL
+		}
+	} else {
+		return first.URL
+	}
+}
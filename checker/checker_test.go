@@ -0,0 +1,112 @@
+package checker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRankSitesDefaultWeightsReproducesLatencyTTRAverage(t *testing.T) {
+	stats := map[string]*SiteStats{
+		"fast": {URL: "fast", AvgLatency: 100, AvgTTR: 300, SuccessCount: 1},
+		"slow": {URL: "slow", AvgLatency: 200, AvgTTR: 200, SuccessCount: 1},
+	}
+
+	ranked := RankSites(stats, nil)
+	if len(ranked) != 2 {
+		t.Fatalf("got %d ranked sites, want 2", len(ranked))
+	}
+
+	// fast: latency rank 1, ttr rank 2 -> combined 1.5
+	// slow: latency rank 2, ttr rank 1 -> combined 1.5
+	// Tied combined ranks; both orderings are valid, but the ranks
+	// themselves must be exactly this.
+	byURL := make(map[string]RankedSite, 2)
+	for _, r := range ranked {
+		byURL[r.URL] = r
+	}
+
+	if got := byURL["fast"].Rank(MetricLatency); got != 1 {
+		t.Errorf("fast latency rank = %d, want 1", got)
+	}
+	if got := byURL["fast"].Rank(MetricTTR); got != 2 {
+		t.Errorf("fast ttr rank = %d, want 2", got)
+	}
+	if got := byURL["slow"].CombinedRank; got != 1.5 {
+		t.Errorf("slow combined rank = %v, want 1.5", got)
+	}
+}
+
+func TestRankSitesCustomWeightsFavorTheWeightedMetric(t *testing.T) {
+	stats := map[string]*SiteStats{
+		"goodLCP": {URL: "goodLCP", AvgLatency: 200, AvgLCP: 100, SuccessCount: 1},
+		"badLCP":  {URL: "badLCP", AvgLatency: 100, AvgLCP: 500, SuccessCount: 1},
+	}
+
+	ranked := RankSites(stats, []MetricWeight{{Metric: MetricLCP, Weight: 1}})
+	if len(ranked) != 2 {
+		t.Fatalf("got %d ranked sites, want 2", len(ranked))
+	}
+	if ranked[0].URL != "goodLCP" {
+		t.Errorf("top ranked site = %q, want %q", ranked[0].URL, "goodLCP")
+	}
+}
+
+func TestRankSitesExcludesSitesWithNoSuccesses(t *testing.T) {
+	stats := map[string]*SiteStats{
+		"ok":     {URL: "ok", AvgLatency: 100, SuccessCount: 1},
+		"broken": {URL: "broken", FailureCount: 3},
+	}
+
+	ranked := RankSites(stats, nil)
+	if len(ranked) != 1 || ranked[0].URL != "ok" {
+		t.Errorf("ranked = %+v, want only %q", ranked, "ok")
+	}
+}
+
+func TestParseMetricWeightsEmptySpecReturnsNil(t *testing.T) {
+	weights, err := ParseMetricWeights("")
+	if err != nil {
+		t.Fatalf("ParseMetricWeights: %v", err)
+	}
+	if weights != nil {
+		t.Errorf("weights = %+v, want nil", weights)
+	}
+}
+
+func TestParseMetricWeightsParsesEachPair(t *testing.T) {
+	weights, err := ParseMetricWeights("latency:1,lcp:2.5")
+	if err != nil {
+		t.Fatalf("ParseMetricWeights: %v", err)
+	}
+
+	want := []MetricWeight{{Metric: MetricLatency, Weight: 1}, {Metric: MetricLCP, Weight: 2.5}}
+	if !reflect.DeepEqual(weights, want) {
+		t.Errorf("weights = %+v, want %+v", weights, want)
+	}
+}
+
+func TestParseMetricWeightsRejectsMalformedPairs(t *testing.T) {
+	for _, spec := range []string{"latency", "latency:notanumber"} {
+		if _, err := ParseMetricWeights(spec); err == nil {
+			t.Errorf("ParseMetricWeights(%q) = nil error, want an error", spec)
+		}
+	}
+}
+
+func TestParseMetricWeightsRejectsUnknownMetric(t *testing.T) {
+	if _, err := ParseMetricWeights("latancy:1"); err == nil {
+		t.Error("ParseMetricWeights(\"latancy:1\") = nil error, want an error")
+	}
+}
+
+func TestFormatMetricWeightsRoundTripsThroughParse(t *testing.T) {
+	weights := []MetricWeight{{Metric: MetricLatency, Weight: 1}, {Metric: MetricCLS, Weight: 0.5}}
+
+	parsed, err := ParseMetricWeights(FormatMetricWeights(weights))
+	if err != nil {
+		t.Fatalf("ParseMetricWeights: %v", err)
+	}
+	if !reflect.DeepEqual(parsed, weights) {
+		t.Errorf("round-tripped weights = %+v, want %+v", parsed, weights)
+	}
+}
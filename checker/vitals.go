@@ -0,0 +1,55 @@
+package checker
+
+import "encoding/json"
+
+// vitalsShim is evaluated in the page before any of its own scripts run
+// (via Page.addScriptToEvaluateOnNewDocument) so the PerformanceObserver
+// entries it reports are never missed. Each observed entry is reported to
+// Go through the "sendVital" binding installed by MeasureMetrics, which
+// surfaces as a Runtime.bindingCalled event.
+const vitalsShim = `(function() {
+	function send(name, value) {
+		if (window.sendVital) {
+			window.sendVital(JSON.stringify({name: name, value: value}));
+		}
+	}
+	try {
+		new PerformanceObserver(function(list) {
+			list.getEntries().forEach(function(entry) {
+				send('lcp', entry.startTime);
+			});
+		}).observe({type: 'largest-contentful-paint', buffered: true});
+	} catch (e) {}
+	try {
+		new PerformanceObserver(function(list) {
+			list.getEntries().forEach(function(entry) {
+				if (entry.name === 'first-contentful-paint') {
+					send('fcp', entry.startTime);
+				}
+			});
+		}).observe({type: 'paint', buffered: true});
+	} catch (e) {}
+	try {
+		var cls = 0;
+		new PerformanceObserver(function(list) {
+			list.getEntries().forEach(function(entry) {
+				if (!entry.hadRecentInput) {
+					cls += entry.value;
+					send('cls', cls);
+				}
+			});
+		}).observe({type: 'layout-shift', buffered: true});
+	} catch (e) {}
+})();`
+
+// vitalPayload is the JSON shape sent by vitalsShim through the sendVital binding.
+type vitalPayload struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+func parseVitalPayload(raw string) (vitalPayload, error) {
+	var p vitalPayload
+	err := json.Unmarshal([]byte(raw), &p)
+	return p, err
+}
@@ -0,0 +1,340 @@
+// Command checker measures and ranks the Flashscore mirrors' page-load
+// performance. Its default subcommand ("run", also the implicit default)
+// measures sites and prints a report; "server" instead serves the
+// dashboard described in package server.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tarun-pacifica/site-speed-checker/checker"
+	"github.com/tarun-pacifica/site-speed-checker/config"
+	"github.com/tarun-pacifica/site-speed-checker/server"
+	"github.com/tarun-pacifica/site-speed-checker/store"
+)
+
+// defaultConfig reproduces the checker's original hard-coded site list and
+// tunables, used when neither --config nor a KV watch is configured.
+func defaultConfig() config.Config {
+	return config.Config{
+		Sites: []string{
+			"https://www.flashscore.co.ke",
+			"https://www.flashscore.co.za",
+			"https://www.flashscore.com",
+			"https://www.flashscore.info",
+			"https://www.flashscore.com.au",
+			"https://www.flashscore.com.ng",
+			"https://www.flashscore.ca",
+			"https://www.flashscore.in",
+			"https://www.flashscore.ae",
+			"https://www.flashscore.co.uk",
+		},
+		Runs:             3,
+		ConcurrencyLimit: 10,
+		ThresholdPercent: 2.0,
+	}
+}
+
+// newWatcher builds a config.Store for the requested backend ("etcd" or
+// "consul"), or returns a nil Store (and nil error) if backend is empty.
+func newWatcher(backend string, endpoints []string) (config.Store, error) {
+	switch backend {
+	case "":
+		return nil, nil
+	case "etcd":
+		return config.NewEtcdWatcher(endpoints)
+	case "consul":
+		address := ""
+		if len(endpoints) > 0 {
+			address = endpoints[0]
+		}
+		return config.NewConsulWatcher(address)
+	default:
+		return nil, fmt.Errorf("unknown --kv-backend %q (want \"etcd\" or \"consul\")", backend)
+	}
+}
+
+// liveConfig holds the Config the measurement loop should use for its next
+// run boundary, kept up to date by a KV watch in the background.
+type liveConfig struct {
+	mu  sync.RWMutex
+	cfg config.Config
+}
+
+func (l *liveConfig) Load() config.Config {
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	return l.cfg
+}
+
+// Store records cfg as the config the next run boundary should use.
+// cfg is sanitized first so a KV update can't hand the measurement loop
+// an unusable ConcurrencyLimit/Runs (e.g. 0, which deadlocks every worker
+// goroutine).
+func (l *liveConfig) Store(cfg config.Config) {
+	cfg.Sanitize()
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cfg = cfg
+}
+
+func main() {
+	args := os.Args[1:]
+	if len(args) > 0 && args[0] == "server" {
+		runServerCommand(args[1:])
+		return
+	}
+	if len(args) > 0 && args[0] == "run" {
+		args = args[1:]
+	}
+	runCheckerCommand(args)
+}
+
+// kvFlags are the --kv-* flags shared by both subcommands.
+type kvFlags struct {
+	backend   *string
+	endpoints *string
+	key       *string
+}
+
+func addKVFlags(fs *flag.FlagSet) kvFlags {
+	return kvFlags{
+		backend:   fs.String("kv-backend", "", "KV backend to watch for live config updates: \"etcd\" or \"consul\" (disabled when empty)"),
+		endpoints: fs.String("kv-endpoints", "", "comma-separated KV backend endpoints"),
+		key:       fs.String("kv-key", "site-speed-checker/config", "KV key (etcd) or path (consul) holding the JSON config"),
+	}
+}
+
+func runCheckerCommand(args []string) {
+	fs := flag.NewFlagSet("checker run", flag.ExitOnError)
+	dbURL := fs.String("db-url", "", "PostgreSQL connection string for persisting samples (optional; persistence is skipped when empty)")
+	windowDays := fs.Int("window-days", 0, "rank sites over this many days of persisted history instead of just this run's batch (requires --db-url)")
+	metrics := fs.String("metrics", "", "comma-separated metric:weight pairs for CombinedRank, e.g. \"latency:1,ttr:1,lcp:2,cls:1\" (overrides the config's metric_weights; default: latency:1,ttr:1)")
+	configPath := fs.String("config", "", "path to a JSON config file (sites, runs, concurrency_limit, threshold_percent); falls back to built-in defaults when empty")
+	kv := addKVFlags(fs)
+	watch := fs.Bool("watch", false, "keep running, re-checking every run boundary, instead of exiting after one batch")
+	fs.Parse(args)
+
+	flagWeights, err := checker.ParseMetricWeights(*metrics)
+	if err != nil {
+		log.Fatalf("--metrics: %v", err)
+	}
+
+	cfg := defaultConfig()
+	if *configPath != "" {
+		cfg, err = config.Load(*configPath)
+		if err != nil {
+			log.Fatalf("config: %v", err)
+		}
+	}
+
+	db, err := store.Open(*dbURL)
+	if err != nil {
+		log.Fatalf("store: %v", err)
+	}
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go db.RunDailyAggregation(ctx)
+
+	live := &liveConfig{}
+	live.Store(cfg)
+
+	if *kv.backend != "" {
+		watcher, err := newWatcher(*kv.backend, strings.Split(*kv.endpoints, ","))
+		if err != nil {
+			log.Fatalf("config: %v", err)
+		}
+		updates, err := watcher.Watch(ctx, *kv.key)
+		if err != nil {
+			log.Fatalf("config: watch %s: %v", *kv.key, err)
+		}
+		go func() {
+			for c := range updates {
+				live.Store(c)
+				log.Printf("config: reloaded from %s watch: %d sites, runs=%d, concurrency=%d, threshold=%.2f%%",
+					*kv.backend, len(c.Sites), c.Runs, c.ConcurrencyLimit, c.ThresholdPercent)
+			}
+		}()
+	}
+
+	for {
+		runOnce(ctx, live, db, flagWeights, *windowDays)
+		if !*watch {
+			break
+		}
+	}
+}
+
+func runServerCommand(args []string) {
+	fs := flag.NewFlagSet("checker server", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "address to serve the dashboard on")
+	dbURL := fs.String("db-url", "", "PostgreSQL connection string to read rankings and history from (required)")
+	windowDays := fs.Int("window-days", 7, "how many days of persisted history the dashboard ranks and charts over")
+	metrics := fs.String("metrics", "", "comma-separated metric:weight pairs for CombinedRank (default: latency:1,ttr:1, or the config's metric_weights if set)")
+	kv := addKVFlags(fs)
+	fs.Parse(args)
+
+	if *dbURL == "" {
+		log.Fatal("server: --db-url is required")
+	}
+
+	flagWeights, err := checker.ParseMetricWeights(*metrics)
+	if err != nil {
+		log.Fatalf("--metrics: %v", err)
+	}
+
+	db, err := store.Open(*dbURL)
+	if err != nil {
+		log.Fatalf("store: %v", err)
+	}
+	defer db.Close()
+
+	var cfgStore config.Store
+	if *kv.backend != "" {
+		cfgStore, err = newWatcher(*kv.backend, strings.Split(*kv.endpoints, ","))
+		if err != nil {
+			log.Fatalf("config: %v", err)
+		}
+	}
+
+	srv := server.New(db, cfgStore, *kv.key, *windowDays, flagWeights)
+
+	log.Printf("Serving dashboard on %s", *addr)
+	if err := http.ListenAndServe(*addr, srv.Handler()); err != nil {
+		log.Fatalf("server: %v", err)
+	}
+}
+
+// runOnce measures every site in the live config's current run boundary,
+// optionally persists the samples, ranks the sites and prints a report.
+func runOnce(ctx context.Context, live *liveConfig, db *store.Store, flagWeights []checker.MetricWeight, windowDays int) {
+	cfg := live.Load()
+
+	log.Printf("Starting metrics tests with %d runs and concurrency limit of %d...\n", cfg.Runs, cfg.ConcurrencyLimit)
+	allResults := checker.RunMetricsTests(cfg.Sites, cfg.Runs, cfg.ConcurrencyLimit)
+
+	now := time.Now()
+	for _, run := range allResults {
+		if err := db.RecordRun(ctx, run, now); err != nil {
+			log.Printf("store: recording run: %v", err)
+		}
+	}
+
+	var stats map[string]*checker.SiteStats
+	var err error
+	if windowDays > 0 {
+		stats, err = db.StatsWindow(ctx, now.AddDate(0, 0, -windowDays))
+		if err != nil {
+			log.Fatalf("store: stats window: %v", err)
+		}
+		fmt.Printf("\nRanking over the trailing %d day(s) of persisted history:\n", windowDays)
+	} else {
+		stats = checker.CalculateStats(allResults)
+	}
+
+	weights := flagWeights
+	if weights == nil {
+		weights = cfg.MetricWeights
+	}
+	rankedSites := checker.RankSites(stats, weights)
+
+	printReport(rankedSites)
+
+	if len(rankedSites) > 0 {
+		// Re-read the threshold here rather than using the cfg captured at
+		// the top of this run: a KV watch may have pushed a new value while
+		// the batch above was running.
+		thresholdPercent := live.Load().ThresholdPercent
+		selectedURL := checker.SelectFlashscoreURL(rankedSites, thresholdPercent)
+		fmt.Printf("Selected URL: %s\n", selectedURL)
+		if err := db.RecordSelection(ctx, selectedURL, now); err != nil {
+			log.Printf("store: recording selection: %v", err)
+		}
+	} else {
+		fmt.Println("No successful measurements were made.")
+	}
+}
+
+func printReport(rankedSites []checker.RankedSite) {
+	// Display rankings by Latency
+	fmt.Println("\nRankings by Latency:")
+	sort.Slice(rankedSites, func(i, j int) bool {
+		return rankedSites[i].Rank(checker.MetricLatency) < rankedSites[j].Rank(checker.MetricLatency)
+	})
+	for i, s := range rankedSites {
+		fmt.Printf("%d. %s: Avg Latency: %v, Rank: %d\n", i+1, s.URL, s.AvgLatency, s.Rank(checker.MetricLatency))
+	}
+
+	// Display rankings by TTR
+	fmt.Println("\nRankings by TTR:")
+	sort.Slice(rankedSites, func(i, j int) bool {
+		return rankedSites[i].Rank(checker.MetricTTR) < rankedSites[j].Rank(checker.MetricTTR)
+	})
+	for i, s := range rankedSites {
+		fmt.Printf("%d. %s: Avg TTR: %v, Rank: %d\n", i+1, s.URL, s.AvgTTR, s.Rank(checker.MetricTTR))
+	}
+
+	// Sort by Combined Rank for the final display
+	sort.Slice(rankedSites, func(i, j int) bool {
+		return rankedSites[i].CombinedRank < rankedSites[j].CombinedRank
+	})
+
+	fmt.Println("\nSummary of Flashscore sites metrics (sorted by combined rank):")
+	for i, s := range rankedSites {
+		fmt.Printf("%d. %s:\n", i+1, s.URL)
+		fmt.Printf("   Avg Latency: %v, Min: %v, Max: %v\n", s.AvgLatency, s.MinLatency, s.MaxLatency)
+		fmt.Printf("   Avg TTR: %v, Min: %v, Max: %v\n", s.AvgTTR, s.MinTTR, s.MaxTTR)
+		fmt.Printf("   Avg FCP: %v, Avg LCP: %v, Avg CLS: %.3f, Avg Network Idle: %v\n", s.AvgFCP, s.AvgLCP, s.AvgCLS, s.AvgNetworkIdle)
+		fmt.Printf("   Latency Rank: %d, TTR Rank: %d, Combined Rank: %.2f\n",
+			s.Rank(checker.MetricLatency), s.Rank(checker.MetricTTR), s.CombinedRank)
+		fmt.Printf("   Success: %d, Failures: %d\n", s.SuccessCount, s.FailureCount)
+
+		if i < len(rankedSites)-1 {
+			gap := rankedSites[i+1].CombinedRank - s.CombinedRank
+			percentageDiff := (gap / s.CombinedRank) * 100
+			fmt.Printf("   Gap to next: %.2f (%.2f%%)\n", gap, percentageDiff)
+		}
+		fmt.Println()
+	}
+
+	if len(rankedSites) >= 2 {
+		first := rankedSites[0]
+		second := rankedSites[1]
+		gap := second.CombinedRank - first.CombinedRank
+		percentageDiff := (gap / first.CombinedRank) * 100
+
+		fmt.Println("======================================")
+		fmt.Println("Gap between 1st and 2nd ranked sites:")
+		fmt.Printf("1st: %s (Combined Rank: %.2f)\n", first.URL, first.CombinedRank)
+		fmt.Printf("2nd: %s (Combined Rank: %.2f)\n", second.URL, second.CombinedRank)
+		fmt.Printf("Absolute gap: %.2f\n", gap)
+		fmt.Printf("Percentage difference: %.2f%%\n", percentageDiff)
+		fmt.Println("======================================")
+	}
+
+	if len(rankedSites) > 0 {
+		fastestSite := rankedSites[0]
+		slowestSite := rankedSites[len(rankedSites)-1]
+		totalGap := slowestSite.CombinedRank - fastestSite.CombinedRank
+		averageGap := totalGap / float64(len(rankedSites)-1)
+
+		fmt.Println("\nOverall Statistics:")
+		fmt.Printf("Total Combined Rank range: %.2f\n", totalGap)
+		fmt.Printf("Average gap between sites: %.2f\n", averageGap)
+		fmt.Printf("Percentage difference between fastest and slowest: %.2f%%\n",
+			(totalGap/fastestSite.CombinedRank)*100)
+
+		fmt.Println("======================================")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/language"
+)
+
+// stopwords lists a handful of very high-frequency function words per
+// language - the kind that show up in a large fraction of sentences
+// regardless of topic, which makes them a cheap stand-in for a full n-gram
+// language model when there's no lang attribute or Content-Language header
+// to go on.
+var stopwords = map[string][]string{
+	"en": {"the", "and", "of", "to", "in", "is", "you", "that", "it", "for", "with", "are", "this"},
+	"fr": {"le", "la", "et", "de", "les", "des", "un", "une", "est", "pour", "avec", "dans", "que"},
+	"de": {"der", "die", "und", "das", "den", "von", "mit", "ist", "für", "ein", "eine", "nicht", "auf"},
+	"es": {"el", "la", "de", "y", "los", "las", "un", "una", "es", "para", "con", "en", "que"},
+	"it": {"il", "la", "di", "e", "gli", "le", "un", "una", "è", "per", "con", "che", "non"},
+	"pt": {"o", "a", "de", "e", "os", "as", "um", "uma", "é", "para", "com", "que", "não"},
+	"nl": {"de", "het", "en", "van", "een", "is", "voor", "met", "dat", "niet", "op", "te"},
+	"ru": {"и", "в", "не", "на", "что", "с", "это", "по", "как", "из", "для", "от"},
+	"pl": {"i", "w", "na", "z", "do", "nie", "to", "się", "jest", "dla", "od"},
+	"tr": {"ve", "bir", "bu", "için", "ile", "da", "de", "çok", "ne", "gibi"},
+}
+
+// minStopwordRatio is the fraction of tokenised words that must match a
+// language's stopword list before classifyText trusts the result at all;
+// below this the text is too short or too unlike any known stopword set to
+// say anything useful.
+const minStopwordRatio = 0.03
+
+// classifyText guesses the dominant language of text by stopword
+// frequency: it tokenises to lowercase words and returns whichever
+// language in stopwords has the highest hit ratio, along with that ratio
+// as a confidence score. It returns (language.Und, 0) if no language
+// clears minStopwordRatio.
+func classifyText(text string) (language.Tag, float64) {
+	words := strings.Fields(strings.ToLower(text))
+	if len(words) == 0 {
+		return language.Und, 0
+	}
+
+	sets := make(map[string]map[string]bool, len(stopwords))
+	for code, list := range stopwords {
+		set := make(map[string]bool, len(list))
+		for _, w := range list {
+			set[w] = true
+		}
+		sets[code] = set
+	}
+
+	hits := make(map[string]int, len(stopwords))
+	for _, w := range words {
+		w = strings.Trim(w, ".,!?:;\"'()")
+		for code, set := range sets {
+			if set[w] {
+				hits[code]++
+			}
+		}
+	}
+
+	var bestCode string
+	var bestRatio float64
+	for code, count := range hits {
+		ratio := float64(count) / float64(len(words))
+		if ratio > bestRatio {
+			bestCode, bestRatio = code, ratio
+		}
+	}
+
+	if bestRatio < minStopwordRatio {
+		return language.Und, 0
+	}
+	return language.MustParse(bestCode), bestRatio
+}
+
+// visibleText walks n's subtree and concatenates the text of every node
+// that isn't inside a <script> or <style> element - a rough approximation
+// of what a reader (rather than a browser's layout engine) would actually
+// see.
+func visibleText(n *html.Node) string {
+	var sb strings.Builder
+	collectVisibleText(n, &sb)
+	return sb.String()
+}
+
+func collectVisibleText(n *html.Node, sb *strings.Builder) {
+	if n.Type == html.ElementNode && (n.Data == "script" || n.Data == "style") {
+		return
+	}
+	if n.Type == html.TextNode {
+		sb.WriteString(n.Data)
+		sb.WriteByte(' ')
+	}
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		collectVisibleText(c, sb)
+	}
+}
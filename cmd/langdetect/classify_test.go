@@ -0,0 +1,37 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestClassifyTextDetectsDominantLanguage(t *testing.T) {
+	tag, score := classifyText("The quick fox and the dog are in the house for it")
+	if base, _ := tag.Base(); base.String() != "en" {
+		t.Errorf("tag = %v, want en", tag)
+	}
+	if score <= 0 {
+		t.Errorf("score = %v, want > 0", score)
+	}
+}
+
+func TestClassifyTextReturnsUndWhenNoWords(t *testing.T) {
+	tag, score := classifyText("")
+	if tag != language.Und {
+		t.Errorf("tag = %v, want Und", tag)
+	}
+	if score != 0 {
+		t.Errorf("score = %v, want 0", score)
+	}
+}
+
+func TestClassifyTextReturnsUndBelowMinRatio(t *testing.T) {
+	tag, score := classifyText("xyzzy plugh qwerty asdf zxcvb")
+	if tag != language.Und {
+		t.Errorf("tag = %v, want Und", tag)
+	}
+	if score != 0 {
+		t.Errorf("score = %v, want 0", score)
+	}
+}
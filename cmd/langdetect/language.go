@@ -0,0 +1,179 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/net/html"
+	"golang.org/x/text/language"
+)
+
+// maxBodyBytes caps how much of a response DetectLanguage reads - enough
+// for the <head> and a representative slice of body text, without
+// downloading an entire page just to guess its language.
+const maxBodyBytes = 512 * 1024
+
+// DetectLanguage fetches url and estimates its language, combining three
+// signals: the Content-Language response header, the <html lang> (or
+// <meta http-equiv="content-language">) attribute, and a stopword-frequency
+// classifier run over the page's visible text. The signals are reconciled
+// with a language.Matcher seeded from CLDR-derived defaults for url's
+// region (see regionDefaultTags), so e.g. a Kenyan site's ambiguous text
+// resolves toward Swahili or English rather than whatever else happens to
+// share stopwords. It returns the resolved tag and a 0-1 confidence score.
+func DetectLanguage(url string) (language.Tag, float64, error) {
+	supported := regionDefaultTags(url)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return language.Und, 0, fmt.Errorf("langdetect: %s: %w", url, err)
+	}
+	req.Header.Set("Accept-Language", acceptLanguageHeader(supported))
+
+	client := &http.Client{Timeout: 10 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return language.Und, 0, fmt.Errorf("langdetect: fetch %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBodyBytes))
+	if err != nil {
+		return language.Und, 0, fmt.Errorf("langdetect: read %s: %w", url, err)
+	}
+
+	doc, err := html.Parse(bytes.NewReader(body))
+	if err != nil {
+		return language.Und, 0, fmt.Errorf("langdetect: parse %s: %w", url, err)
+	}
+
+	var candidates []language.Tag
+
+	if tag, ok := parseContentLanguage(resp.Header.Get("Content-Language")); ok {
+		candidates = append(candidates, tag)
+	}
+	if tag, ok := parseLangAttribute(doc); ok {
+		candidates = append(candidates, tag)
+	}
+
+	textTag, textScore := classifyText(visibleText(doc))
+	if textScore > 0 {
+		candidates = append(candidates, textTag)
+	}
+
+	if len(candidates) == 0 {
+		// Nothing to go on beyond the site's region - report the region's
+		// most likely language with low confidence rather than Und.
+		return supported[0], 0.1, nil
+	}
+
+	matcher := language.NewMatcher(supported)
+	tag, _, confidence := matcher.Match(candidates...)
+	return tag, confidenceScore(confidence, textScore), nil
+}
+
+func confidenceScore(c language.Confidence, textScore float64) float64 {
+	switch c {
+	case language.Exact:
+		return 1.0
+	case language.High:
+		return 0.85
+	case language.Low:
+		return 0.5 + 0.3*textScore
+	default:
+		return 0.2 * textScore
+	}
+}
+
+// acceptLanguageHeader renders tags as an Accept-Language header value,
+// most-preferred first, so a server that does content negotiation is more
+// likely to report an accurate Content-Language back.
+func acceptLanguageHeader(tags []language.Tag) string {
+	parts := make([]string, len(tags))
+	for i, t := range tags {
+		if i == 0 {
+			parts[i] = t.String()
+			continue
+		}
+		parts[i] = fmt.Sprintf("%s;q=%.1f", t, 1.0-float64(i)*0.1)
+	}
+	return strings.Join(parts, ",")
+}
+
+// parseContentLanguage parses the first tag out of a Content-Language
+// header value, which may list several comma-separated tags.
+func parseContentLanguage(header string) (language.Tag, bool) {
+	header = strings.TrimSpace(strings.SplitN(header, ",", 2)[0])
+	if header == "" {
+		return language.Und, false
+	}
+
+	tag, err := language.Parse(header)
+	if err != nil {
+		return language.Und, false
+	}
+	return canonicalize(tag), true
+}
+
+// parseLangAttribute finds the <html lang="..."> attribute, falling back
+// to <meta http-equiv="content-language" content="...">, and parses
+// whichever it finds.
+func parseLangAttribute(n *html.Node) (language.Tag, bool) {
+	if code, ok := findLangAttribute(n); ok {
+		tag, err := language.Parse(code)
+		if err != nil {
+			return language.Und, false
+		}
+		return canonicalize(tag), true
+	}
+	return language.Und, false
+}
+
+// canonicalize resolves legacy and macro-language tags (e.g. "iw" for
+// Hebrew, or a bare macrolanguage like "zh") to their modern/preferred
+// form, falling back to tag unchanged if canonicalization fails.
+func canonicalize(tag language.Tag) language.Tag {
+	canonical, err := language.All.Canonicalize(tag)
+	if err != nil {
+		return tag
+	}
+	return canonical
+}
+
+func findLangAttribute(n *html.Node) (string, bool) {
+	if n.Type == html.ElementNode {
+		switch n.Data {
+		case "html":
+			if code, ok := attrValue(n, "lang"); ok && code != "" {
+				return code, true
+			}
+		case "meta":
+			if httpEquiv, _ := attrValue(n, "http-equiv"); strings.EqualFold(httpEquiv, "content-language") {
+				if code, ok := attrValue(n, "content"); ok {
+					return strings.SplitN(code, ",", 2)[0], true
+				}
+			}
+		}
+	}
+
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		if code, ok := findLangAttribute(c); ok {
+			return code, true
+		}
+	}
+
+	return "", false
+}
+
+func attrValue(n *html.Node, key string) (string, bool) {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"testing"
+
+	"golang.org/x/text/language"
+)
+
+func TestParseContentLanguageParsesFirstTag(t *testing.T) {
+	tag, ok := parseContentLanguage("en-US, fr-FR")
+	if !ok {
+		t.Fatal("parseContentLanguage returned ok=false, want true")
+	}
+	if base, _ := tag.Base(); base.String() != "en" {
+		t.Errorf("tag = %v, want en-US", tag)
+	}
+}
+
+func TestParseContentLanguageRejectsEmptyOrInvalid(t *testing.T) {
+	if _, ok := parseContentLanguage(""); ok {
+		t.Error("empty header: ok = true, want false")
+	}
+	if _, ok := parseContentLanguage("not-a-real-tag-!!"); ok {
+		t.Error("invalid header: ok = true, want false")
+	}
+}
+
+func TestCanonicalizeResolvesLegacyTag(t *testing.T) {
+	iw := language.MustParse("iw")
+	got := canonicalize(iw)
+	if got.String() != "he" {
+		t.Errorf("canonicalize(iw) = %v, want he", got)
+	}
+}
+
+func TestCanonicalizeLeavesModernTagUnchanged(t *testing.T) {
+	en := language.MustParse("en")
+	got := canonicalize(en)
+	if got != en {
+		t.Errorf("canonicalize(en) = %v, want en", got)
+	}
+}
@@ -1,24 +1,18 @@
-/*
-* @Author: Tarun Mookhey
-* @Date:   2024-10-06 17:38:17
-* @Last Modified by:   Tarun Mookhey
-* @Last Modified time: 2024-10-06 17:44:17
- */
+// Command langdetect reports which of the Flashscore mirrors serve English
+// content, using DetectLanguage's header/attribute/text-classifier signals
+// rather than assuming a TLD implies a language.
 package main
 
 import (
-	"fmt"
-	"io"
-	"net/http"
-	"strings"
+	"log"
 	"sync"
-	"time"
-
-	"golang.org/x/net/html"
-	"golang.org/x/text/language"
-	"golang.org/x/text/language/display"
 )
 
+// minEnglishConfidence is the default threshold detectEnglishSites uses:
+// below this, DetectLanguage's guess is treated as too unreliable to act
+// on either way.
+const minEnglishConfidence = 0.5
+
 func main() {
 	validSites := []string{
 		"https://www.flashscore.com.au", // Australia
@@ -125,15 +119,19 @@ func main() {
 		"https://www.flashscore.jo",     // Jordan
 		"https://www.flashscore.lb",     // Lebanon
 	}
-	englishSites := detectEnglishSites(validSites)
 
-	fmt.Println("Sites in English:")
+	englishSites := detectEnglishSites(validSites, minEnglishConfidence)
+
+	log.Println("Sites in English:")
 	for _, site := range englishSites {
-		fmt.Println(site)
+		log.Println(site)
 	}
 }
 
-func detectEnglishSites(sites []string) []string {
+// detectEnglishSites returns the subset of sites DetectLanguage resolves
+// to English with at least minConfidence. Sites it fails to fetch or
+// parse are logged and excluded, not treated as a match.
+func detectEnglishSites(sites []string, minConfidence float64) []string {
 	var wg sync.WaitGroup
 	results := make(chan string, len(sites))
 
@@ -141,7 +139,15 @@ func detectEnglishSites(sites []string) []string {
 		wg.Add(1)
 		go func(url string) {
 			defer wg.Done()
-			if isEnglish(url) {
+
+			tag, confidence, err := DetectLanguage(url)
+			if err != nil {
+				log.Printf("langdetect: %v", err)
+				return
+			}
+
+			base, _ := tag.Base()
+			if base.String() == "en" && confidence >= minConfidence {
 				results <- url
 			}
 		}(site)
@@ -159,72 +165,3 @@ func detectEnglishSites(sites []string) []string {
 
 	return englishSites
 }
-
-func isEnglish(url string) bool {
-	client := &http.Client{
-		Timeout: 10 * time.Second,
-	}
-
-	resp, err := client.Get(url)
-	if err != nil {
-		fmt.Printf("Error fetching %s: %v\n", url, err)
-		return false
-	}
-	defer resp.Body.Close()
-
-	content, err := io.ReadAll(resp.Body)
-	if err != nil {
-		fmt.Printf("Error reading content from %s: %v\n", url, err)
-		return false
-	}
-
-	doc, err := html.Parse(strings.NewReader(string(content)))
-	if err != nil {
-		fmt.Printf("Error parsing HTML from %s: %v\n", url, err)
-		return false
-	}
-
-	lang := extractLanguage(doc)
-	if lang == "" {
-		lang = detectLanguage(content)
-	}
-
-	return strings.HasPrefix(lang, "en")
-}
-
-func extractLanguage(n *html.Node) string {
-	if n.Type == html.ElementNode && n.Data == "html" {
-		for _, attr := range n.Attr {
-			if attr.Key == "lang" {
-				return attr.Val
-			}
-		}
-	}
-
-	for c := n.FirstChild; c != nil; c = c.NextSibling {
-		if lang := extractLanguage(c); lang != "" {
-			return lang
-		}
-	}
-
-	return ""
-}
-
-func detectLanguage(content []byte) string {
-	langs := []language.Tag{
-		language.English,
-		language.French,
-		language.German,
-		language.Italian,
-		language.Spanish,
-		language.Portuguese,
-		language.Russian,
-		language.Japanese,
-		language.Korean,
-		// Add more languages as needed
-	}
-
-	matcher := language.NewMatcher(langs)
-	tag, _ := language.MatchStrings(matcher, string(content))
-	return display.English.Tags().Name(tag)
-}
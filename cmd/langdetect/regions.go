@@ -0,0 +1,152 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+
+	"golang.org/x/text/language"
+)
+
+// regionLanguages maps a site's TLD (or, for ccSLDs like "co.uk", the last
+// two labels) to the languages CLDR's supplemental data associates with
+// that region, most-likely first. It only needs to cover the TLDs actually
+// in use below; unknown TLDs fall back to English.
+var regionLanguages = map[string][]language.Tag{
+	"fr":     tags("fr"),
+	"es":     tags("es"),
+	"de":     tags("de"),
+	"it":     tags("it"),
+	"pt":     tags("pt"),
+	"com.br": tags("pt"),
+	"ru":     tags("ru"),
+	"jp":     tags("ja"),
+	"kr":     tags("ko"),
+	"pl":     tags("pl"),
+	"nl":     tags("nl"),
+	"co.in":  tags("en", "hi"),
+	"com.tr": tags("tr"),
+	"com.eg": tags("ar", "en"),
+	"sa":     tags("ar"),
+	"ae":     tags("ar", "en"),
+	"gr":     tags("el"),
+	"be":     tags("nl", "fr"),
+	"se":     tags("sv"),
+	"no":     tags("nb"),
+	"dk":     tags("da"),
+	"fi":     tags("fi"),
+	"cz":     tags("cs"),
+	"hu":     tags("hu"),
+	"ro":     tags("ro"),
+	"bg":     tags("bg"),
+	"at":     tags("de"),
+	"ch":     tags("de", "fr", "it"),
+	"ie":     tags("en"),
+	"ua":     tags("uk"),
+	"hr":     tags("hr"),
+	"rs":     tags("sr"),
+	"sk":     tags("sk"),
+	"si":     tags("sl"),
+	"lv":     tags("lv"),
+	"lt":     tags("lt"),
+	"ee":     tags("et"),
+	"com.my": tags("ms", "en"),
+	"com.sg": tags("en", "ms"),
+	"com.ph": tags("en", "fil"),
+	"co.th":  tags("th"),
+	"co.id":  tags("id"),
+	"vn":     tags("vi"),
+	"hk":     tags("zh-Hant", "en"),
+	"tw":     tags("zh-Hant"),
+	"co.nz":  tags("en"),
+	"co.za":  tags("en", "af"),
+	"co.ke":  tags("sw", "en"),
+	"com.ng": tags("en"),
+	"com.gh": tags("en"),
+	"com.tn": tags("ar", "fr"),
+	"dz":     tags("ar", "fr"),
+	"ma":     tags("ar", "fr"),
+	"sn":     tags("fr"),
+	"ci":     tags("fr"),
+	"cm":     tags("fr", "en"),
+	"com.uy": tags("es"),
+	"com.py": tags("es"),
+	"com.bo": tags("es"),
+	"com.ec": tags("es"),
+	"com.ve": tags("es"),
+	"com.co": tags("es"),
+	"com.gt": tags("es"),
+	"com.sv": tags("es"),
+	"hn":     tags("es"),
+	"com.ni": tags("es"),
+	"com.pa": tags("es"),
+	"com.do": tags("es"),
+	"com.cu": tags("es"),
+	"com.pr": tags("es", "en"),
+	"is":     tags("is"),
+	"lu":     tags("fr", "de"),
+	"mt":     tags("en", "mt"),
+	"cy":     tags("el", "en"),
+	"by":     tags("ru"),
+	"md":     tags("ro", "ru"),
+	"am":     tags("hy"),
+	"ge":     tags("ka"),
+	"az":     tags("az"),
+	"kz":     tags("ru", "kk"),
+	"uz":     tags("uz"),
+	"kg":     tags("ky"),
+	"tj":     tags("ru"),
+	"tm":     tags("tk"),
+	"mn":     tags("mn"),
+	"np":     tags("ne"),
+	"lk":     tags("si", "en"),
+	"bd":     tags("bn"),
+	"pk":     tags("ur", "en"),
+	"af":     tags("fa", "ps"),
+	"ir":     tags("fa"),
+	"iq":     tags("ar"),
+	"jo":     tags("ar"),
+	"lb":     tags("ar", "fr"),
+	"com.ar": tags("es"),
+	"cl":     tags("es"),
+	"co":     tags("es"),
+	"pe":     tags("es"),
+	"mx":     tags("es"),
+	"com.au": tags("en"),
+	"co.uk":  tags("en"),
+	"com":    tags("en"),
+}
+
+func tags(codes ...string) []language.Tag {
+	out := make([]language.Tag, len(codes))
+	for i, code := range codes {
+		out[i] = language.MustParse(code)
+	}
+	return out
+}
+
+// regionDefaultTags returns the CLDR-derived language defaults for url's
+// region, used to seed the language.Matcher in DetectLanguage. It checks
+// the last two domain labels first (for ccSLDs like "co.uk" or "com.br")
+// before falling back to the TLD alone, and defaults to English if the
+// region isn't in regionLanguages.
+func regionDefaultTags(rawURL string) []language.Tag {
+	host := rawURL
+	if u, err := url.Parse(rawURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	host = strings.TrimPrefix(host, "www.")
+
+	labels := strings.Split(host, ".")
+	if len(labels) >= 3 {
+		if tags, ok := regionLanguages[strings.Join(labels[len(labels)-2:], ".")]; ok {
+			return tags
+		}
+	}
+	if len(labels) >= 2 {
+		if tags, ok := regionLanguages[labels[len(labels)-1]]; ok {
+			return tags
+		}
+	}
+
+	return regionLanguages["com"]
+}
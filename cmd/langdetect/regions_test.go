@@ -0,0 +1,32 @@
+package main
+
+import "testing"
+
+func TestRegionDefaultTagsMatchesCCSLD(t *testing.T) {
+	got := regionDefaultTags("https://www.flashscore.co.uk")
+	if len(got) == 0 || got[0].String() != "en" {
+		t.Errorf("regionDefaultTags(co.uk) = %v, want [en ...]", got)
+	}
+}
+
+func TestRegionDefaultTagsMatchesPlainTLD(t *testing.T) {
+	got := regionDefaultTags("https://www.flashscore.fr")
+	if len(got) == 0 || got[0].String() != "fr" {
+		t.Errorf("regionDefaultTags(fr) = %v, want [fr]", got)
+	}
+}
+
+func TestRegionDefaultTagsFallsBackToCom(t *testing.T) {
+	got := regionDefaultTags("https://www.flashscore.example.unknown-tld")
+	want := regionLanguages["com"]
+	if len(got) != len(want) || got[0] != want[0] {
+		t.Errorf("regionDefaultTags(unknown) = %v, want %v", got, want)
+	}
+}
+
+func TestRegionDefaultTagsHandlesBareHost(t *testing.T) {
+	got := regionDefaultTags("www.flashscore.com.br")
+	if len(got) == 0 || got[0].String() != "pt" {
+		t.Errorf("regionDefaultTags(com.br) = %v, want [pt]", got)
+	}
+}
@@ -0,0 +1,58 @@
+// Package config loads the checker's tunables - the site list, run count,
+// concurrency limit and tie-break threshold - from a file and, optionally,
+// keeps them in sync with a KV backend (etcd or Consul) so an operator can
+// push changes without restarting the checker.
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tarun-pacifica/site-speed-checker/checker"
+)
+
+// ErrNotFound is returned by Store.Get when the requested key doesn't
+// exist yet.
+var ErrNotFound = errors.New("config: key not found")
+
+// Config holds everything that used to be hard-coded constants in main.
+type Config struct {
+	Sites            []string               `json:"sites"`
+	Runs             int                    `json:"runs"`
+	ConcurrencyLimit int                    `json:"concurrency_limit"`
+	ThresholdPercent float64                `json:"threshold_percent"`
+	MetricWeights    []checker.MetricWeight `json:"metric_weights,omitempty"`
+}
+
+// Load reads a JSON-encoded Config from path.
+func Load(path string) (Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: read %s: %w", path, err)
+	}
+
+	var c Config
+	if err := json.Unmarshal(data, &c); err != nil {
+		return Config{}, fmt.Errorf("config: parse %s: %w", path, err)
+	}
+
+	c.Sanitize()
+	return c, nil
+}
+
+// Sanitize clamps fields that would otherwise reach the measurement loop
+// as unusable values - most importantly ConcurrencyLimit, which is used
+// as a channel buffer size and deadlocks every worker goroutine if it's
+// zero or negative. Callers that take a Config from anywhere other than
+// Load (e.g. a KV watch update) should call Sanitize before storing or
+// acting on it.
+func (c *Config) Sanitize() {
+	if c.ConcurrencyLimit < 1 {
+		c.ConcurrencyLimit = 1
+	}
+	if c.Runs < 1 {
+		c.Runs = 1
+	}
+}
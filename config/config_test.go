@@ -0,0 +1,57 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSanitizesZeroConcurrencyAndRuns(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"sites":["https://example.com"],"runs":0,"concurrency_limit":0,"threshold_percent":2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.ConcurrencyLimit != 1 {
+		t.Errorf("ConcurrencyLimit = %d, want 1", cfg.ConcurrencyLimit)
+	}
+	if cfg.Runs != 1 {
+		t.Errorf("Runs = %d, want 1", cfg.Runs)
+	}
+}
+
+func TestLoadLeavesValidValuesAlone(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.json")
+	if err := os.WriteFile(path, []byte(`{"sites":["https://example.com"],"runs":5,"concurrency_limit":10,"threshold_percent":2}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.ConcurrencyLimit != 10 {
+		t.Errorf("ConcurrencyLimit = %d, want 10", cfg.ConcurrencyLimit)
+	}
+	if cfg.Runs != 5 {
+		t.Errorf("Runs = %d, want 5", cfg.Runs)
+	}
+}
+
+func TestSanitizeClampsNegativeValues(t *testing.T) {
+	cfg := Config{ConcurrencyLimit: -3, Runs: -1}
+	cfg.Sanitize()
+
+	if cfg.ConcurrencyLimit != 1 {
+		t.Errorf("ConcurrencyLimit = %d, want 1", cfg.ConcurrencyLimit)
+	}
+	if cfg.Runs != 1 {
+		t.Errorf("Runs = %d, want 1", cfg.Runs)
+	}
+}
@@ -0,0 +1,103 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/hashicorp/consul/api"
+)
+
+// ConsulWatcher is a Watcher backed by a Consul KV store, using blocking
+// queries to wait for changes instead of polling.
+type ConsulWatcher struct {
+	client *api.Client
+}
+
+// NewConsulWatcher connects to the Consul agent at address (empty uses the
+// client's default, http://127.0.0.1:8500).
+func NewConsulWatcher(address string) (*ConsulWatcher, error) {
+	client, err := api.NewClient(&api.Config{Address: address})
+	if err != nil {
+		return nil, fmt.Errorf("config: consul: %w", err)
+	}
+
+	return &ConsulWatcher{client: client}, nil
+}
+
+// Watch implements Watcher.
+func (w *ConsulWatcher) Watch(ctx context.Context, key string) (<-chan Config, error) {
+	out := make(chan Config, 1)
+	kv := w.client.KV()
+
+	go func() {
+		defer close(out)
+
+		var lastIndex uint64
+		for ctx.Err() == nil {
+			pair, meta, err := kv.Get(key, (&api.QueryOptions{
+				WaitIndex: lastIndex,
+				WaitTime:  5 * time.Minute,
+			}).WithContext(ctx))
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Printf("config: consul: watch %s: %v", key, err)
+				time.Sleep(time.Second)
+				continue
+			}
+			if pair == nil {
+				lastIndex = meta.LastIndex
+				continue
+			}
+			lastIndex = meta.LastIndex
+
+			var c Config
+			if err := json.Unmarshal(pair.Value, &c); err != nil {
+				log.Printf("config: consul: invalid config at %s: %v", key, err)
+				continue
+			}
+
+			select {
+			case out <- c:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Get implements Store.
+func (w *ConsulWatcher) Get(ctx context.Context, key string) (Config, error) {
+	pair, _, err := w.client.KV().Get(key, (&api.QueryOptions{}).WithContext(ctx))
+	if err != nil {
+		return Config{}, fmt.Errorf("config: consul: get %s: %w", key, err)
+	}
+	if pair == nil {
+		return Config{}, ErrNotFound
+	}
+
+	var c Config
+	if err := json.Unmarshal(pair.Value, &c); err != nil {
+		return Config{}, fmt.Errorf("config: consul: invalid config at %s: %w", key, err)
+	}
+	return c, nil
+}
+
+// Put implements Store by writing cfg as JSON to key.
+func (w *ConsulWatcher) Put(ctx context.Context, key string, cfg Config) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("config: consul: marshal: %w", err)
+	}
+	_, err = w.client.KV().Put(&api.KVPair{Key: key, Value: data}, (&api.WriteOptions{}).WithContext(ctx))
+	if err != nil {
+		return fmt.Errorf("config: consul: put %s: %w", key, err)
+	}
+	return nil
+}
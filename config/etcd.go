@@ -0,0 +1,103 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// EtcdWatcher is a Watcher backed by an etcd v3 cluster.
+type EtcdWatcher struct {
+	client *clientv3.Client
+}
+
+// NewEtcdWatcher connects to the given etcd endpoints.
+func NewEtcdWatcher(endpoints []string) (*EtcdWatcher, error) {
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   endpoints,
+		DialTimeout: 5 * time.Second,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("config: etcd: %w", err)
+	}
+
+	return &EtcdWatcher{client: client}, nil
+}
+
+// Close closes the underlying etcd client.
+func (w *EtcdWatcher) Close() error {
+	return w.client.Close()
+}
+
+// Watch implements Watcher.
+func (w *EtcdWatcher) Watch(ctx context.Context, key string) (<-chan Config, error) {
+	resp, err := w.client.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("config: etcd: get %s: %w", key, err)
+	}
+
+	out := make(chan Config, 1)
+
+	emit := func(value []byte) {
+		var c Config
+		if err := json.Unmarshal(value, &c); err != nil {
+			log.Printf("config: etcd: invalid config at %s: %v", key, err)
+			return
+		}
+		select {
+		case out <- c:
+		case <-ctx.Done():
+		}
+	}
+
+	if len(resp.Kvs) > 0 {
+		emit(resp.Kvs[0].Value)
+	}
+
+	watchChan := w.client.Watch(ctx, key)
+	go func() {
+		defer close(out)
+		for wresp := range watchChan {
+			for _, ev := range wresp.Events {
+				if ev.Type == clientv3.EventTypePut {
+					emit(ev.Kv.Value)
+				}
+			}
+		}
+	}()
+
+	return out, nil
+}
+
+// Get implements Store.
+func (w *EtcdWatcher) Get(ctx context.Context, key string) (Config, error) {
+	resp, err := w.client.Get(ctx, key)
+	if err != nil {
+		return Config{}, fmt.Errorf("config: etcd: get %s: %w", key, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return Config{}, ErrNotFound
+	}
+
+	var c Config
+	if err := json.Unmarshal(resp.Kvs[0].Value, &c); err != nil {
+		return Config{}, fmt.Errorf("config: etcd: invalid config at %s: %w", key, err)
+	}
+	return c, nil
+}
+
+// Put implements Store by writing cfg as JSON to key.
+func (w *EtcdWatcher) Put(ctx context.Context, key string, cfg Config) error {
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return fmt.Errorf("config: etcd: marshal: %w", err)
+	}
+	if _, err := w.client.Put(ctx, key, string(data)); err != nil {
+		return fmt.Errorf("config: etcd: put %s: %w", key, err)
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package config
+
+import "context"
+
+// Watcher watches a single KV key for Config updates. It mirrors the
+// watchChanges pattern traefik's KV providers use: Watch returns a
+// long-lived channel immediately, and a Config is pushed onto it once for
+// the key's current value and again every time that value changes, until
+// ctx is cancelled (at which point the channel is closed).
+type Watcher interface {
+	Watch(ctx context.Context, key string) (<-chan Config, error)
+}
+
+// Store is a Watcher that can also read the current value of a key
+// without watching it, and push a new Config to the KV backend - e.g. from
+// the dashboard's settings page.
+type Store interface {
+	Watcher
+	Get(ctx context.Context, key string) (Config, error)
+	Put(ctx context.Context, key string, cfg Config) error
+}
@@ -0,0 +1,53 @@
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+
+	"github.com/tarun-pacifica/site-speed-checker/store"
+)
+
+const (
+	chartWidth  = 480
+	chartHeight = 80
+)
+
+// sparkline renders daily's value(d) series as a minimal inline SVG line
+// chart, with no JS or charting library involved - just enough to show a
+// trend on the history page.
+func sparkline(daily []store.DailyStat, value func(store.DailyStat) float64) template.HTML {
+	if len(daily) == 0 {
+		return ""
+	}
+
+	min, max := value(daily[0]), value(daily[0])
+	for _, d := range daily {
+		v := value(d)
+		if v < min {
+			min = v
+		}
+		if v > max {
+			max = v
+		}
+	}
+
+	var points []string
+	span := max - min
+	for i, d := range daily {
+		denom := len(daily) - 1
+		if denom < 1 {
+			denom = 1
+		}
+		x := float64(i) * chartWidth / float64(denom)
+		y := float64(chartHeight - 4)
+		if span > 0 {
+			y = float64(chartHeight-4) - ((value(d)-min)/span)*float64(chartHeight-8)
+		}
+		points = append(points, fmt.Sprintf("%.1f,%.1f", x, y))
+	}
+
+	return template.HTML(fmt.Sprintf(
+		`<svg viewBox="0 0 %d %d" class="sparkline" xmlns="http://www.w3.org/2000/svg"><polyline points="%s" fill="none" stroke="currentColor" stroke-width="2"/></svg>`,
+		chartWidth, chartHeight, strings.Join(points, " ")))
+}
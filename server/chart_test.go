@@ -0,0 +1,50 @@
+package server
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tarun-pacifica/site-speed-checker/store"
+)
+
+func TestSparklineEmptyInputReturnsEmptyString(t *testing.T) {
+	got := sparkline(nil, func(store.DailyStat) float64 { return 0 })
+	if got != "" {
+		t.Errorf("sparkline(nil) = %q, want empty", got)
+	}
+}
+
+func TestSparklineRendersAPointPerDay(t *testing.T) {
+	daily := []store.DailyStat{
+		{Day: day(1), AvgLatency: 100 * time.Millisecond},
+		{Day: day(2), AvgLatency: 200 * time.Millisecond},
+		{Day: day(3), AvgLatency: 150 * time.Millisecond},
+	}
+
+	got := sparkline(daily, func(d store.DailyStat) float64 { return float64(d.AvgLatency) })
+	if !strings.Contains(string(got), "<svg") || !strings.Contains(string(got), "<polyline") {
+		t.Fatalf("sparkline output missing svg/polyline: %s", got)
+	}
+
+	points := strings.Count(string(got), ",")
+	if points != len(daily) {
+		t.Errorf("sparkline rendered %d points, want %d", points, len(daily))
+	}
+}
+
+func TestSparklineConstantSeriesDoesNotDivideByZeroSpan(t *testing.T) {
+	daily := []store.DailyStat{
+		{Day: day(1), AvgLatency: 100 * time.Millisecond},
+		{Day: day(2), AvgLatency: 100 * time.Millisecond},
+	}
+
+	got := sparkline(daily, func(d store.DailyStat) float64 { return float64(d.AvgLatency) })
+	if !strings.Contains(string(got), "<svg") {
+		t.Fatalf("sparkline output missing svg: %s", got)
+	}
+}
+
+func day(n int) time.Time {
+	return time.Date(2026, time.January, n, 0, 0, 0, 0, time.UTC)
+}
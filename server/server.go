@@ -0,0 +1,307 @@
+// Package server exposes the checker's latest rankings, per-URL history
+// and currently-selected Flashscore URL over HTTP, as an HTML dashboard
+// plus a small JSON API.
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/tarun-pacifica/site-speed-checker/checker"
+	"github.com/tarun-pacifica/site-speed-checker/config"
+	"github.com/tarun-pacifica/site-speed-checker/store"
+)
+
+// Server serves the dashboard. It reads rankings and history from db and,
+// if cfgStore is non-nil, reads and writes the live config the measurement
+// loop's KV watch consumes at cfgKey.
+type Server struct {
+	db         *store.Store
+	cfgStore   config.Store
+	cfgKey     string
+	windowDays int
+	weights    []checker.MetricWeight
+}
+
+// New builds a Server. cfgStore may be nil, in which case /settings is
+// read-only (there's nowhere to persist edits). weights overrides whatever
+// metric_weights the config holds; pass nil to prefer the config's
+// weights, falling back to checker.DefaultMetricWeights.
+func New(db *store.Store, cfgStore config.Store, cfgKey string, windowDays int, weights []checker.MetricWeight) *Server {
+	return &Server{db: db, cfgStore: cfgStore, cfgKey: cfgKey, windowDays: windowDays, weights: weights}
+}
+
+// Handler returns the Server's http.Handler.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.handleIndex)
+	mux.HandleFunc("/history/", s.handleHistory)
+	mux.HandleFunc("/settings", s.handleSettings)
+	mux.HandleFunc("/theme", s.handleTheme)
+	mux.HandleFunc("/api/rankings", s.handleAPIRankings)
+	mux.HandleFunc("/api/stats/", s.handleAPIStats)
+	mux.HandleFunc("/api/selected", s.handleAPISelected)
+	return mux
+}
+
+// encodeURL and decodeURL round-trip a site URL through a path segment.
+// ServeMux cleans paths (collapsing the "//" in "https://..."), so a raw
+// URL can't be embedded directly - it's base64-encoded instead.
+func encodeURL(url string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(url))
+}
+
+func decodeURL(segment string) (string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return "", fmt.Errorf("invalid url segment: %w", err)
+	}
+	return string(data), nil
+}
+
+// effectiveWeights returns s.weights if set, otherwise the config's
+// metric_weights (read from cfgStore, if configured), otherwise
+// checker.DefaultMetricWeights.
+func (s *Server) effectiveWeights(r *http.Request) []checker.MetricWeight {
+	if len(s.weights) > 0 {
+		return s.weights
+	}
+	if s.cfgStore != nil {
+		if cfg, err := s.cfgStore.Get(r.Context(), s.cfgKey); err == nil && len(cfg.MetricWeights) > 0 {
+			return cfg.MetricWeights
+		}
+	}
+	return checker.DefaultMetricWeights
+}
+
+func (s *Server) rankedSites(r *http.Request) ([]checker.RankedSite, error) {
+	since := time.Now().AddDate(0, 0, -s.windowDays)
+	stats, err := s.db.StatsWindow(r.Context(), since)
+	if err != nil {
+		return nil, err
+	}
+	return checker.RankSites(stats, s.effectiveWeights(r)), nil
+}
+
+func (s *Server) handleIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+
+	rankedSites, err := s.rankedSites(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	selectedURL, selectedAt, err := s.db.LatestSelection(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := indexData{
+		Theme:       themeFromCookie(r),
+		WindowDays:  s.windowDays,
+		Sites:       rankedSites,
+		SelectedURL: selectedURL,
+		SelectedAt:  selectedAt,
+		EncodeURL:   encodeURL,
+	}
+	if err := indexTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleHistory(w http.ResponseWriter, r *http.Request) {
+	segment := strings.TrimPrefix(r.URL.Path, "/history/")
+	url, err := decodeURL(segment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -s.windowDays)
+	daily, err := s.db.DailyStats(r.Context(), url, since)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	data := historyData{
+		Theme:        themeFromCookie(r),
+		URL:          url,
+		WindowDays:   s.windowDays,
+		Daily:        daily,
+		LatencyChart: sparkline(daily, func(d store.DailyStat) float64 { return float64(d.AvgLatency) }),
+		TTRChart:     sparkline(daily, func(d store.DailyStat) float64 { return float64(d.AvgTTR) }),
+		LCPChart:     sparkline(daily, func(d store.DailyStat) float64 { return float64(d.AvgLCP) }),
+	}
+	if err := historyTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleSettings(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodPost {
+		s.handleSettingsPost(w, r)
+		return
+	}
+
+	data := settingsData{
+		Theme:    themeFromCookie(r),
+		ReadOnly: s.cfgStore == nil,
+	}
+
+	if s.cfgStore != nil {
+		cfg, err := s.cfgStore.Get(r.Context(), s.cfgKey)
+		if err != nil && err != config.ErrNotFound {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		data.Config = cfg
+		data.MetricWeightsText = checker.FormatMetricWeights(cfg.MetricWeights)
+	}
+
+	if err := settingsTemplate.Execute(w, data); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) handleSettingsPost(w http.ResponseWriter, r *http.Request) {
+	if s.cfgStore == nil {
+		http.Error(w, "settings: no KV backend configured, this dashboard is read-only", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	cfg, err := s.cfgStore.Get(r.Context(), s.cfgKey)
+	if err != nil && err != config.ErrNotFound {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	cfg.Sites = splitLines(r.FormValue("sites"))
+	if cfg.Runs, err = formInt(r, "runs"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if cfg.ConcurrencyLimit, err = formInt(r, "concurrency_limit"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if cfg.ThresholdPercent, err = formFloat(r, "threshold_percent"); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cfg.MetricWeights, err = checker.ParseMetricWeights(r.FormValue("metric_weights"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	cfg.Sanitize()
+
+	if err := s.cfgStore.Put(r.Context(), s.cfgKey, cfg); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/settings", http.StatusSeeOther)
+}
+
+func (s *Server) handleTheme(w http.ResponseWriter, r *http.Request) {
+	theme := r.FormValue("theme")
+	if theme != "dark" {
+		theme = "light"
+	}
+	http.SetCookie(w, &http.Cookie{Name: themeCookie, Value: theme, Path: "/", MaxAge: 365 * 24 * 60 * 60})
+
+	redirectTo := r.FormValue("redirect")
+	if redirectTo == "" {
+		redirectTo = "/"
+	}
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+func (s *Server) handleAPIRankings(w http.ResponseWriter, r *http.Request) {
+	rankedSites, err := s.rankedSites(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, rankedSites)
+}
+
+func (s *Server) handleAPIStats(w http.ResponseWriter, r *http.Request) {
+	segment := strings.TrimPrefix(r.URL.Path, "/api/stats/")
+	url, err := decodeURL(segment)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	since := time.Now().AddDate(0, 0, -s.windowDays)
+	daily, err := s.db.DailyStats(r.Context(), url, since)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, daily)
+}
+
+func (s *Server) handleAPISelected(w http.ResponseWriter, r *http.Request) {
+	url, ts, err := s.db.LatestSelection(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	writeJSON(w, struct {
+		URL        string    `json:"url"`
+		SelectedAt time.Time `json:"selected_at"`
+	}{url, ts})
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func splitLines(text string) []string {
+	var out []string
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func formInt(r *http.Request, name string) (int, error) {
+	var v int
+	_, err := fmt.Sscanf(r.FormValue(name), "%d", &v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
+
+func formFloat(r *http.Request, name string) (float64, error) {
+	var v float64
+	_, err := fmt.Sscanf(r.FormValue(name), "%g", &v)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %w", name, err)
+	}
+	return v, nil
+}
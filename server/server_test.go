@@ -0,0 +1,183 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/tarun-pacifica/site-speed-checker/config"
+)
+
+// memStore is a minimal in-memory config.Store for exercising the
+// /settings handlers without a real etcd/Consul backend.
+type memStore struct {
+	cfg config.Config
+	set bool
+}
+
+func (m *memStore) Watch(ctx context.Context, key string) (<-chan config.Config, error) {
+	ch := make(chan config.Config)
+	close(ch)
+	return ch, nil
+}
+
+func (m *memStore) Get(ctx context.Context, key string) (config.Config, error) {
+	if !m.set {
+		return config.Config{}, config.ErrNotFound
+	}
+	return m.cfg, nil
+}
+
+func (m *memStore) Put(ctx context.Context, key string, cfg config.Config) error {
+	m.cfg = cfg
+	m.set = true
+	return nil
+}
+
+func TestHandleAPIRankingsOnEmptyStoreReturnsEmptyArray(t *testing.T) {
+	s := New(nil, nil, "", 7, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/rankings", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if got := strings.TrimSpace(rec.Body.String()); got != "null" && got != "[]" {
+		t.Errorf("body = %q, want an empty JSON array", got)
+	}
+}
+
+func TestHandleAPISelectedOnEmptyStoreReturnsEmptyURL(t *testing.T) {
+	s := New(nil, nil, "", 7, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/selected", nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if !strings.Contains(rec.Body.String(), `"url":""`) {
+		t.Errorf("body = %q, want an empty url field", rec.Body.String())
+	}
+}
+
+func TestHandleSettingsPostWithoutCfgStoreIsReadOnly(t *testing.T) {
+	s := New(nil, nil, "cfg", 7, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/settings", strings.NewReader(url.Values{
+		"sites": {"https://example.com"},
+		"runs":  {"1"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusServiceUnavailable {
+		t.Errorf("status = %d, want 503", rec.Code)
+	}
+}
+
+func TestHandleSettingsPostRejectsMalformedRuns(t *testing.T) {
+	s := New(nil, &memStore{}, "cfg", 7, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/settings", strings.NewReader(url.Values{
+		"sites":             {"https://example.com"},
+		"runs":              {"not-a-number"},
+		"concurrency_limit": {"1"},
+		"threshold_percent": {"2"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleSettingsPostRejectsUnknownMetric(t *testing.T) {
+	s := New(nil, &memStore{}, "cfg", 7, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/settings", strings.NewReader(url.Values{
+		"sites":             {"https://example.com"},
+		"runs":              {"1"},
+		"concurrency_limit": {"1"},
+		"threshold_percent": {"2"},
+		"metric_weights":    {"latancy:1"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Errorf("status = %d, want 400", rec.Code)
+	}
+}
+
+func TestHandleSettingsPostStoresValidConfig(t *testing.T) {
+	cfgStore := &memStore{}
+	s := New(nil, cfgStore, "cfg", 7, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/settings", strings.NewReader(url.Values{
+		"sites":             {"https://example.com"},
+		"runs":              {"3"},
+		"concurrency_limit": {"5"},
+		"threshold_percent": {"2"},
+		"metric_weights":    {"latency:1,lcp:2"},
+	}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, want 303", rec.Code)
+	}
+	if !cfgStore.set {
+		t.Fatal("config was not stored")
+	}
+	if cfgStore.cfg.Runs != 3 || cfgStore.cfg.ConcurrencyLimit != 5 {
+		t.Errorf("stored config = %+v, want Runs=3 ConcurrencyLimit=5", cfgStore.cfg)
+	}
+}
+
+func TestHandleHistoryRendersUnescapedSVG(t *testing.T) {
+	s := New(nil, nil, "", 7, nil)
+
+	req := httptest.NewRequest(http.MethodGet, "/history/"+encodeURL("https://example.com"), nil)
+	rec := httptest.NewRecorder()
+	s.Handler().ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200", rec.Code)
+	}
+	if strings.Contains(rec.Body.String(), "&lt;svg") {
+		t.Error("history page escaped the chart markup instead of rendering it")
+	}
+}
+
+func TestHistoryTemplateRendersChartsAsUnescapedSVG(t *testing.T) {
+	var buf strings.Builder
+	data := historyData{
+		URL:          "https://example.com",
+		LatencyChart: "<svg>latency</svg>",
+		TTRChart:     "<svg>ttr</svg>",
+		LCPChart:     "<svg>lcp</svg>",
+	}
+	if err := historyTemplate.Execute(&buf, data); err != nil {
+		t.Fatalf("Execute: %v", err)
+	}
+
+	rendered := buf.String()
+	if !strings.Contains(rendered, "<svg>latency</svg>") {
+		t.Errorf("rendered output escaped LatencyChart: %s", rendered)
+	}
+	if strings.Contains(rendered, "&lt;svg&gt;") {
+		t.Errorf("rendered output escaped a chart: %s", rendered)
+	}
+}
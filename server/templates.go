@@ -0,0 +1,155 @@
+package server
+
+import (
+	"html/template"
+	"net/http"
+	"time"
+
+	"github.com/tarun-pacifica/site-speed-checker/checker"
+	"github.com/tarun-pacifica/site-speed-checker/config"
+	"github.com/tarun-pacifica/site-speed-checker/store"
+)
+
+// themeCookie is the cookie name the light/dark toggle persists to,
+// following the same settings-cookie pattern as Spitfire's theme switch.
+const themeCookie = "theme"
+
+func themeFromCookie(r *http.Request) string {
+	if c, err := r.Cookie(themeCookie); err == nil && c.Value == "dark" {
+		return "dark"
+	}
+	return "light"
+}
+
+type indexData struct {
+	Theme       string
+	WindowDays  int
+	Sites       []checker.RankedSite
+	SelectedURL string
+	SelectedAt  time.Time
+	EncodeURL   func(string) string
+}
+
+type historyData struct {
+	Theme        string
+	URL          string
+	WindowDays   int
+	Daily        []store.DailyStat
+	LatencyChart template.HTML
+	TTRChart     template.HTML
+	LCPChart     template.HTML
+}
+
+type settingsData struct {
+	Theme             string
+	ReadOnly          bool
+	Config            config.Config
+	MetricWeightsText string
+}
+
+// css is served inline rather than as a static asset, keeping the
+// dashboard a single self-contained binary like the rest of this repo.
+const css = `
+:root { color-scheme: light; --bg: #fff; --fg: #111; --muted: #666; --border: #ddd; --accent: #2563eb; }
+html[data-theme="dark"] { color-scheme: dark; --bg: #111; --fg: #eee; --muted: #999; --border: #333; --accent: #60a5fa; }
+body { background: var(--bg); color: var(--fg); font-family: system-ui, sans-serif; margin: 2rem; }
+table { border-collapse: collapse; width: 100%; }
+th, td { text-align: left; padding: 0.4rem 0.8rem; border-bottom: 1px solid var(--border); }
+a { color: var(--accent); }
+.muted { color: var(--muted); }
+.sparkline { color: var(--accent); width: 100%; height: 80px; }
+nav { margin-bottom: 1.5rem; }
+nav a { margin-right: 1rem; }
+form.theme-toggle { display: inline; }
+input, textarea { background: var(--bg); color: var(--fg); border: 1px solid var(--border); padding: 0.3rem; }
+label { display: block; margin-top: 0.8rem; }
+`
+
+var funcs = template.FuncMap{
+	"inc": func(i int) int { return i + 1 },
+}
+
+var baseTemplate = template.Must(template.New("base").Funcs(funcs).Parse(`
+<!doctype html>
+<html data-theme="{{.Theme}}">
+<head>
+<meta charset="utf-8">
+<title>site-speed-checker</title>
+<style>` + css + `</style>
+</head>
+<body>
+<nav>
+<a href="/">Rankings</a>
+<a href="/settings">Settings</a>
+<form class="theme-toggle" method="post" action="/theme">
+<input type="hidden" name="redirect" value="{{.Theme}}">
+<button name="theme" value="{{if eq .Theme "dark"}}light{{else}}dark{{end}}">
+{{if eq .Theme "dark"}}Light mode{{else}}Dark mode{{end}}
+</button>
+</form>
+</nav>
+{{template "content" .}}
+</body>
+</html>
+`))
+
+var indexTemplate = template.Must(template.Must(baseTemplate.Clone()).Parse(`
+{{define "content"}}
+<h1>Rankings (trailing {{.WindowDays}} day(s))</h1>
+{{if .SelectedURL}}<p>Currently selected: <a href="{{.SelectedURL}}">{{.SelectedURL}}</a> <span class="muted">(as of {{.SelectedAt.Format "2006-01-02 15:04:05"}})</span></p>{{end}}
+<table>
+<tr><th>#</th><th>URL</th><th>Avg Latency</th><th>Avg TTR</th><th>Avg LCP</th><th>Avg CLS</th><th>Combined Rank</th><th>Success/Failure</th></tr>
+{{range $i, $s := .Sites}}
+<tr>
+<td>{{inc $i}}</td>
+<td><a href="/history/{{call $.EncodeURL $s.URL}}">{{$s.URL}}</a></td>
+<td>{{$s.AvgLatency}}</td>
+<td>{{$s.AvgTTR}}</td>
+<td>{{$s.AvgLCP}}</td>
+<td>{{printf "%.3f" $s.AvgCLS}}</td>
+<td>{{printf "%.2f" $s.CombinedRank}}</td>
+<td>{{$s.SuccessCount}}/{{$s.FailureCount}}</td>
+</tr>
+{{end}}
+</table>
+{{end}}
+`))
+
+var historyTemplate = template.Must(template.Must(baseTemplate.Clone()).Parse(`
+{{define "content"}}
+<h1>{{.URL}}</h1>
+<p class="muted">Trailing {{.WindowDays}} day(s)</p>
+<h2>Latency</h2>
+{{.LatencyChart}}
+<h2>TTR</h2>
+{{.TTRChart}}
+<h2>LCP</h2>
+{{.LCPChart}}
+<table>
+<tr><th>Day</th><th>Avg Latency</th><th>Avg TTR</th><th>Avg LCP</th><th>Avg CLS</th><th>Success/Failure</th></tr>
+{{range .Daily}}
+<tr><td>{{.Day.Format "2006-01-02"}}</td><td>{{.AvgLatency}}</td><td>{{.AvgTTR}}</td><td>{{.AvgLCP}}</td><td>{{printf "%.3f" .AvgCLS}}</td><td>{{.SuccessCount}}/{{.FailureCount}}</td></tr>
+{{end}}
+</table>
+{{end}}
+`))
+
+var settingsTemplate = template.Must(template.Must(baseTemplate.Clone()).Parse(`
+{{define "content"}}
+<h1>Settings</h1>
+{{if .ReadOnly}}
+<p class="muted">No KV backend is configured for this dashboard, so settings are read-only.</p>
+{{end}}
+<form method="post" action="/settings">
+<label>Sites (one per line)
+<textarea name="sites" rows="8" cols="60" {{if .ReadOnly}}disabled{{end}}>{{range .Config.Sites}}{{.}}
+{{end}}</textarea>
+</label>
+<label>Runs <input type="number" name="runs" value="{{.Config.Runs}}" {{if .ReadOnly}}disabled{{end}}></label>
+<label>Concurrency limit <input type="number" name="concurrency_limit" value="{{.Config.ConcurrencyLimit}}" {{if .ReadOnly}}disabled{{end}}></label>
+<label>Threshold percent <input type="number" step="0.01" name="threshold_percent" value="{{.Config.ThresholdPercent}}" {{if .ReadOnly}}disabled{{end}}></label>
+<label>Metric weights <input type="text" name="metric_weights" value="{{.MetricWeightsText}}" placeholder="latency:1,ttr:1" {{if .ReadOnly}}disabled{{end}}></label>
+{{if not .ReadOnly}}<p><button type="submit">Save</button></p>{{end}}
+</form>
+{{end}}
+`))
@@ -0,0 +1,508 @@
+// Package store persists SiteMetrics samples to PostgreSQL and rolls them
+// up into daily aggregates so that rankings can be computed over rolling
+// windows instead of a single in-memory batch.
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"time"
+
+	_ "github.com/lib/pq"
+
+	"github.com/tarun-pacifica/site-speed-checker/checker"
+)
+
+const schema = `
+CREATE TABLE IF NOT EXISTS samples (
+	id               BIGSERIAL PRIMARY KEY,
+	url              TEXT NOT NULL,
+	ts               TIMESTAMPTZ NOT NULL,
+	latency_ns       BIGINT NOT NULL,
+	ttr_ns           BIGINT NOT NULL,
+	fcp_ns           BIGINT NOT NULL DEFAULT 0,
+	lcp_ns           BIGINT NOT NULL DEFAULT 0,
+	cls              DOUBLE PRECISION NOT NULL DEFAULT 0,
+	network_idle_ns  BIGINT NOT NULL DEFAULT 0,
+	error            TEXT
+);
+CREATE INDEX IF NOT EXISTS samples_url_ts_idx ON samples (url, ts);
+
+ALTER TABLE samples ADD COLUMN IF NOT EXISTS fcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE samples ADD COLUMN IF NOT EXISTS lcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE samples ADD COLUMN IF NOT EXISTS cls DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE samples ADD COLUMN IF NOT EXISTS network_idle_ns BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS daily_stats (
+	url                  TEXT NOT NULL,
+	day                  DATE NOT NULL,
+	avg_latency_ns       BIGINT NOT NULL,
+	min_latency_ns       BIGINT NOT NULL,
+	max_latency_ns       BIGINT NOT NULL,
+	p50_latency_ns       BIGINT NOT NULL,
+	p95_latency_ns       BIGINT NOT NULL,
+	avg_ttr_ns           BIGINT NOT NULL,
+	min_ttr_ns           BIGINT NOT NULL,
+	max_ttr_ns           BIGINT NOT NULL,
+	p50_ttr_ns           BIGINT NOT NULL,
+	p95_ttr_ns           BIGINT NOT NULL,
+	avg_fcp_ns           BIGINT NOT NULL DEFAULT 0,
+	min_fcp_ns           BIGINT NOT NULL DEFAULT 0,
+	max_fcp_ns           BIGINT NOT NULL DEFAULT 0,
+	avg_lcp_ns           BIGINT NOT NULL DEFAULT 0,
+	min_lcp_ns           BIGINT NOT NULL DEFAULT 0,
+	max_lcp_ns           BIGINT NOT NULL DEFAULT 0,
+	avg_cls              DOUBLE PRECISION NOT NULL DEFAULT 0,
+	min_cls              DOUBLE PRECISION NOT NULL DEFAULT 0,
+	max_cls              DOUBLE PRECISION NOT NULL DEFAULT 0,
+	avg_network_idle_ns  BIGINT NOT NULL DEFAULT 0,
+	min_network_idle_ns  BIGINT NOT NULL DEFAULT 0,
+	max_network_idle_ns  BIGINT NOT NULL DEFAULT 0,
+	success_count        INT NOT NULL,
+	failure_count        INT NOT NULL,
+	PRIMARY KEY (url, day)
+);
+
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS avg_fcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS min_fcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS max_fcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS avg_lcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS min_lcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS max_lcp_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS avg_cls DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS min_cls DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS max_cls DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS avg_network_idle_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS min_network_idle_ns BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE daily_stats ADD COLUMN IF NOT EXISTS max_network_idle_ns BIGINT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS selected_url (
+	id          INT PRIMARY KEY DEFAULT 1,
+	url         TEXT NOT NULL,
+	selected_at TIMESTAMPTZ NOT NULL,
+	CHECK (id = 1)
+);
+`
+
+// Store is a PostgreSQL-backed persistence layer for SiteMetrics samples
+// and their daily aggregates. A nil *Store is not valid; callers that want
+// a no-op store (e.g. when no --db-url was configured) should simply keep
+// a nil *Store and skip calling it, which every method here tolerates.
+type Store struct {
+	db *sql.DB
+}
+
+// Open connects to dbURL and ensures the schema exists. If dbURL is empty,
+// Open returns a nil *Store and a nil error so callers can treat
+// persistence as an optional, no-op feature.
+func Open(dbURL string) (*Store, error) {
+	if dbURL == "" {
+		return nil, nil
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		return nil, fmt.Errorf("store: open: %w", err)
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: ping: %w", err)
+	}
+
+	if _, err := db.Exec(schema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("store: migrate: %w", err)
+	}
+
+	return &Store{db: db}, nil
+}
+
+// Close closes the underlying database connection. It is safe to call on
+// a nil *Store.
+func (s *Store) Close() error {
+	if s == nil {
+		return nil
+	}
+	return s.db.Close()
+}
+
+// RecordRun writes every sample in results to the samples table, stamped
+// with ts. It is a no-op on a nil *Store.
+func (s *Store) RecordRun(ctx context.Context, results []checker.SiteMetrics, ts time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("store: begin: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.PrepareContext(ctx, `
+		INSERT INTO samples (url, ts, latency_ns, ttr_ns, fcp_ns, lcp_ns, cls, network_idle_ns, error)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`)
+	if err != nil {
+		return fmt.Errorf("store: prepare: %w", err)
+	}
+	defer stmt.Close()
+
+	for _, r := range results {
+		var errText sql.NullString
+		if r.Error != nil {
+			errText = sql.NullString{String: r.Error.Error(), Valid: true}
+		}
+		if _, err := stmt.ExecContext(ctx, r.URL, ts, r.Latency.Nanoseconds(), r.TTR.Nanoseconds(),
+			r.FCP.Nanoseconds(), r.LCP.Nanoseconds(), r.CLS, r.NetworkIdle.Nanoseconds(), errText); err != nil {
+			return fmt.Errorf("store: insert %s: %w", r.URL, err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// RunDailyAggregation rolls up the previous day's samples on startup, then
+// once per day at one minute past midnight, until ctx is cancelled. It
+// follows the same "aggregate now, then sleep until the next boundary"
+// shape as syncthing's usage-reporting aggregator. It is a no-op on a nil
+// *Store.
+func (s *Store) RunDailyAggregation(ctx context.Context) {
+	if s == nil {
+		return
+	}
+
+	for {
+		yesterday := time.Now().AddDate(0, 0, -1)
+		if err := s.AggregateDay(ctx, yesterday); err != nil {
+			log.Printf("store: daily aggregation for %s failed: %v", yesterday.Format("2006-01-02"), err)
+		}
+
+		next := nextAggregationTime(time.Now())
+		select {
+		case <-time.After(time.Until(next)):
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func nextAggregationTime(now time.Time) time.Time {
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 1, 0, 0, now.Location())
+	if !midnight.After(now) {
+		midnight = midnight.AddDate(0, 0, 1)
+	}
+	return midnight
+}
+
+// AggregateDay rolls up every sample recorded on day's calendar date into
+// daily_stats, one row per URL. It is a no-op on a nil *Store.
+func (s *Store) AggregateDay(ctx context.Context, day time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+	end := start.AddDate(0, 0, 1)
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO daily_stats (
+			url, day,
+			avg_latency_ns, min_latency_ns, max_latency_ns, p50_latency_ns, p95_latency_ns,
+			avg_ttr_ns, min_ttr_ns, max_ttr_ns, p50_ttr_ns, p95_ttr_ns,
+			avg_fcp_ns, min_fcp_ns, max_fcp_ns,
+			avg_lcp_ns, min_lcp_ns, max_lcp_ns,
+			avg_cls, min_cls, max_cls,
+			avg_network_idle_ns, min_network_idle_ns, max_network_idle_ns,
+			success_count, failure_count
+		)
+		SELECT
+			url, $1::date,
+			coalesce(avg(latency_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(min(latency_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(max(latency_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(percentile_cont(0.5) WITHIN GROUP (ORDER BY latency_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(percentile_cont(0.95) WITHIN GROUP (ORDER BY latency_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(avg(ttr_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(min(ttr_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(max(ttr_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(percentile_cont(0.5) WITHIN GROUP (ORDER BY ttr_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(percentile_cont(0.95) WITHIN GROUP (ORDER BY ttr_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(avg(fcp_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(min(fcp_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(max(fcp_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(avg(lcp_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(min(lcp_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(max(lcp_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(avg(cls) FILTER (WHERE error IS NULL), 0),
+			coalesce(min(cls) FILTER (WHERE error IS NULL), 0),
+			coalesce(max(cls) FILTER (WHERE error IS NULL), 0),
+			coalesce(avg(network_idle_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(min(network_idle_ns) FILTER (WHERE error IS NULL), 0),
+			coalesce(max(network_idle_ns) FILTER (WHERE error IS NULL), 0),
+			count(*) FILTER (WHERE error IS NULL),
+			count(*) FILTER (WHERE error IS NOT NULL)
+		FROM samples
+		WHERE ts >= $2 AND ts < $3
+		GROUP BY url
+		ON CONFLICT (url, day) DO UPDATE SET
+			avg_latency_ns      = EXCLUDED.avg_latency_ns,
+			min_latency_ns      = EXCLUDED.min_latency_ns,
+			max_latency_ns      = EXCLUDED.max_latency_ns,
+			p50_latency_ns      = EXCLUDED.p50_latency_ns,
+			p95_latency_ns      = EXCLUDED.p95_latency_ns,
+			avg_ttr_ns          = EXCLUDED.avg_ttr_ns,
+			min_ttr_ns          = EXCLUDED.min_ttr_ns,
+			max_ttr_ns          = EXCLUDED.max_ttr_ns,
+			p50_ttr_ns          = EXCLUDED.p50_ttr_ns,
+			p95_ttr_ns          = EXCLUDED.p95_ttr_ns,
+			avg_fcp_ns          = EXCLUDED.avg_fcp_ns,
+			min_fcp_ns          = EXCLUDED.min_fcp_ns,
+			max_fcp_ns          = EXCLUDED.max_fcp_ns,
+			avg_lcp_ns          = EXCLUDED.avg_lcp_ns,
+			min_lcp_ns          = EXCLUDED.min_lcp_ns,
+			max_lcp_ns          = EXCLUDED.max_lcp_ns,
+			avg_cls             = EXCLUDED.avg_cls,
+			min_cls             = EXCLUDED.min_cls,
+			max_cls             = EXCLUDED.max_cls,
+			avg_network_idle_ns = EXCLUDED.avg_network_idle_ns,
+			min_network_idle_ns = EXCLUDED.min_network_idle_ns,
+			max_network_idle_ns = EXCLUDED.max_network_idle_ns,
+			success_count       = EXCLUDED.success_count,
+			failure_count       = EXCLUDED.failure_count
+	`, start.Format("2006-01-02"), start, end)
+	if err != nil {
+		return fmt.Errorf("store: aggregate %s: %w", start.Format("2006-01-02"), err)
+	}
+
+	return nil
+}
+
+// StatsWindow reconstructs per-URL checker.SiteStats from the daily_stats
+// rollups for the N days up to and including today, where since is the
+// earliest day to include. Min/max and success/failure counts combine
+// exactly across days; the combined averages and percentiles are weighted
+// by each day's success_count, which is an approximation of the true
+// percentile over the whole window but avoids re-scanning raw samples.
+func (s *Store) StatsWindow(ctx context.Context, since time.Time) (map[string]*checker.SiteStats, error) {
+	if s == nil {
+		return map[string]*checker.SiteStats{}, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT url,
+			avg_latency_ns, min_latency_ns, max_latency_ns,
+			avg_ttr_ns, min_ttr_ns, max_ttr_ns,
+			avg_fcp_ns, min_fcp_ns, max_fcp_ns,
+			avg_lcp_ns, min_lcp_ns, max_lcp_ns,
+			avg_cls, min_cls, max_cls,
+			avg_network_idle_ns, min_network_idle_ns, max_network_idle_ns,
+			success_count, failure_count
+		FROM daily_stats
+		WHERE day >= $1
+		ORDER BY url`, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("store: stats window: %w", err)
+	}
+	defer rows.Close()
+
+	stats := make(map[string]*checker.SiteStats)
+	weighted := make(map[string]*weightedAverages)
+
+	for rows.Next() {
+		var url string
+		var avgLatencyNs, minLatencyNs, maxLatencyNs int64
+		var avgTTRNs, minTTRNs, maxTTRNs int64
+		var avgFCPNs, minFCPNs, maxFCPNs int64
+		var avgLCPNs, minLCPNs, maxLCPNs int64
+		var avgCLS, minCLS, maxCLS float64
+		var avgNetworkIdleNs, minNetworkIdleNs, maxNetworkIdleNs int64
+		var successCount, failureCount int
+
+		if err := rows.Scan(&url,
+			&avgLatencyNs, &minLatencyNs, &maxLatencyNs,
+			&avgTTRNs, &minTTRNs, &maxTTRNs,
+			&avgFCPNs, &minFCPNs, &maxFCPNs,
+			&avgLCPNs, &minLCPNs, &maxLCPNs,
+			&avgCLS, &minCLS, &maxCLS,
+			&avgNetworkIdleNs, &minNetworkIdleNs, &maxNetworkIdleNs,
+			&successCount, &failureCount); err != nil {
+			return nil, fmt.Errorf("store: stats window scan: %w", err)
+		}
+
+		s, exists := stats[url]
+		if !exists {
+			s = &checker.SiteStats{URL: url,
+				MinLatency: time.Duration(minLatencyNs), MaxLatency: time.Duration(maxLatencyNs),
+				MinTTR: time.Duration(minTTRNs), MaxTTR: time.Duration(maxTTRNs),
+				MinFCP: time.Duration(minFCPNs), MaxFCP: time.Duration(maxFCPNs),
+				MinLCP: time.Duration(minLCPNs), MaxLCP: time.Duration(maxLCPNs),
+				MinCLS: minCLS, MaxCLS: maxCLS,
+				MinNetworkIdle: time.Duration(minNetworkIdleNs), MaxNetworkIdle: time.Duration(maxNetworkIdleNs),
+			}
+			stats[url] = s
+			weighted[url] = &weightedAverages{}
+		}
+
+		if time.Duration(minLatencyNs) < s.MinLatency {
+			s.MinLatency = time.Duration(minLatencyNs)
+		}
+		if time.Duration(maxLatencyNs) > s.MaxLatency {
+			s.MaxLatency = time.Duration(maxLatencyNs)
+		}
+		if time.Duration(minTTRNs) < s.MinTTR {
+			s.MinTTR = time.Duration(minTTRNs)
+		}
+		if time.Duration(maxTTRNs) > s.MaxTTR {
+			s.MaxTTR = time.Duration(maxTTRNs)
+		}
+		if time.Duration(minFCPNs) < s.MinFCP {
+			s.MinFCP = time.Duration(minFCPNs)
+		}
+		if time.Duration(maxFCPNs) > s.MaxFCP {
+			s.MaxFCP = time.Duration(maxFCPNs)
+		}
+		if time.Duration(minLCPNs) < s.MinLCP {
+			s.MinLCP = time.Duration(minLCPNs)
+		}
+		if time.Duration(maxLCPNs) > s.MaxLCP {
+			s.MaxLCP = time.Duration(maxLCPNs)
+		}
+		if minCLS < s.MinCLS {
+			s.MinCLS = minCLS
+		}
+		if maxCLS > s.MaxCLS {
+			s.MaxCLS = maxCLS
+		}
+		if time.Duration(minNetworkIdleNs) < s.MinNetworkIdle {
+			s.MinNetworkIdle = time.Duration(minNetworkIdleNs)
+		}
+		if time.Duration(maxNetworkIdleNs) > s.MaxNetworkIdle {
+			s.MaxNetworkIdle = time.Duration(maxNetworkIdleNs)
+		}
+
+		w := weighted[url]
+		w.latency += float64(avgLatencyNs) * float64(successCount)
+		w.ttr += float64(avgTTRNs) * float64(successCount)
+		w.fcp += float64(avgFCPNs) * float64(successCount)
+		w.lcp += float64(avgLCPNs) * float64(successCount)
+		w.cls += avgCLS * float64(successCount)
+		w.networkIdle += float64(avgNetworkIdleNs) * float64(successCount)
+		s.SuccessCount += successCount
+		s.FailureCount += failureCount
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: stats window rows: %w", err)
+	}
+
+	for url, s := range stats {
+		if s.SuccessCount > 0 {
+			w := weighted[url]
+			n := float64(s.SuccessCount)
+			s.AvgLatency = time.Duration(w.latency / n)
+			s.AvgTTR = time.Duration(w.ttr / n)
+			s.AvgFCP = time.Duration(w.fcp / n)
+			s.AvgLCP = time.Duration(w.lcp / n)
+			s.AvgCLS = w.cls / n
+			s.AvgNetworkIdle = time.Duration(w.networkIdle / n)
+		}
+	}
+
+	return stats, nil
+}
+
+// weightedAverages accumulates each day's avg * success_count for a URL,
+// so StatsWindow can divide by the window's total success_count once at
+// the end instead of averaging-of-averages.
+type weightedAverages struct {
+	latency, ttr, fcp, lcp, cls, networkIdle float64
+}
+
+// DailyStat is a single day's row from daily_stats, used to render
+// per-URL history (e.g. the server package's history charts).
+type DailyStat struct {
+	Day          time.Time
+	AvgLatency   time.Duration
+	AvgTTR       time.Duration
+	AvgFCP       time.Duration
+	AvgLCP       time.Duration
+	AvgCLS       float64
+	SuccessCount int
+	FailureCount int
+}
+
+// DailyStats returns url's daily_stats rows for every day since since, in
+// chronological order. It returns nil on a nil *Store.
+func (s *Store) DailyStats(ctx context.Context, url string, since time.Time) ([]DailyStat, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT day, avg_latency_ns, avg_ttr_ns, avg_fcp_ns, avg_lcp_ns, avg_cls, success_count, failure_count
+		FROM daily_stats
+		WHERE url = $1 AND day >= $2
+		ORDER BY day`, url, since.Format("2006-01-02"))
+	if err != nil {
+		return nil, fmt.Errorf("store: daily stats: %w", err)
+	}
+	defer rows.Close()
+
+	var out []DailyStat
+	for rows.Next() {
+		var d DailyStat
+		var avgLatencyNs, avgTTRNs, avgFCPNs, avgLCPNs int64
+		if err := rows.Scan(&d.Day, &avgLatencyNs, &avgTTRNs, &avgFCPNs, &avgLCPNs, &d.AvgCLS, &d.SuccessCount, &d.FailureCount); err != nil {
+			return nil, fmt.Errorf("store: daily stats scan: %w", err)
+		}
+		d.AvgLatency = time.Duration(avgLatencyNs)
+		d.AvgTTR = time.Duration(avgTTRNs)
+		d.AvgFCP = time.Duration(avgFCPNs)
+		d.AvgLCP = time.Duration(avgLCPNs)
+		out = append(out, d)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("store: daily stats rows: %w", err)
+	}
+
+	return out, nil
+}
+
+// RecordSelection persists the URL selectFlashscoreURL picked for this
+// run, so that it can be served over HTTP (e.g. by the server package's
+// /api/selected endpoint) without the caller needing to re-run a batch.
+// It is a no-op on a nil *Store.
+func (s *Store) RecordSelection(ctx context.Context, url string, ts time.Time) error {
+	if s == nil {
+		return nil
+	}
+
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO selected_url (id, url, selected_at) VALUES (1, $1, $2)
+		ON CONFLICT (id) DO UPDATE SET url = EXCLUDED.url, selected_at = EXCLUDED.selected_at
+	`, url, ts)
+	if err != nil {
+		return fmt.Errorf("store: record selection: %w", err)
+	}
+	return nil
+}
+
+// LatestSelection returns the most recently recorded selection, or an
+// empty url and zero time if none has been recorded yet (including on a
+// nil *Store).
+func (s *Store) LatestSelection(ctx context.Context) (url string, ts time.Time, err error) {
+	if s == nil {
+		return "", time.Time{}, nil
+	}
+
+	err = s.db.QueryRowContext(ctx, `SELECT url, selected_at FROM selected_url WHERE id = 1`).Scan(&url, &ts)
+	if err == sql.ErrNoRows {
+		return "", time.Time{}, nil
+	}
+	if err != nil {
+		return "", time.Time{}, fmt.Errorf("store: latest selection: %w", err)
+	}
+	return url, ts, nil
+}
@@ -0,0 +1,46 @@
+package store
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNextAggregationTime(t *testing.T) {
+	loc := time.UTC
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want time.Time
+	}{
+		{
+			name: "before today's run time",
+			now:  time.Date(2026, time.March, 10, 0, 0, 30, 0, loc),
+			want: time.Date(2026, time.March, 10, 0, 1, 0, 0, loc),
+		},
+		{
+			name: "exactly at today's run time",
+			now:  time.Date(2026, time.March, 10, 0, 1, 0, 0, loc),
+			want: time.Date(2026, time.March, 11, 0, 1, 0, 0, loc),
+		},
+		{
+			name: "after today's run time",
+			now:  time.Date(2026, time.March, 10, 12, 0, 0, 0, loc),
+			want: time.Date(2026, time.March, 11, 0, 1, 0, 0, loc),
+		},
+		{
+			name: "rolls over a month boundary",
+			now:  time.Date(2026, time.March, 31, 23, 59, 0, 0, loc),
+			want: time.Date(2026, time.April, 1, 0, 1, 0, 0, loc),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nextAggregationTime(tt.now)
+			if !got.Equal(tt.want) {
+				t.Errorf("nextAggregationTime(%v) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}